@@ -0,0 +1,131 @@
+package api
+
+import (
+	"context"
+	"strings"
+)
+
+// Analytics job statuses.
+const (
+	AnalyticsRunning = "running"
+	AnalyticsDone    = "done"
+	AnalyticsFailed  = "failed"
+)
+
+// AnalyticsFilters restricts an analytics report or export to resources.
+type AnalyticsFilters struct {
+	AccountIDs  []string `json:"account_ids,omitempty"`
+	ChannelIDs  []string `json:"channel_ids,omitempty"`
+	InboxIDs    []string `json:"inbox_ids,omitempty"`
+	TagIDs      []string `json:"tag_ids,omitempty"`
+	TeammateIDs []string `json:"teammate_ids,omitempty"`
+}
+
+// Empty reports whether no filter is set.
+func (f AnalyticsFilters) Empty() bool {
+	return len(f.AccountIDs)+len(f.ChannelIDs)+len(f.InboxIDs)+len(f.TagIDs)+len(f.TeammateIDs) == 0
+}
+
+// AnalyticsReportRequest creates an analytics report.
+type AnalyticsReportRequest struct {
+	Start    float64           `json:"start"`
+	End      float64           `json:"end"`
+	Timezone string            `json:"timezone,omitempty"`
+	Filters  *AnalyticsFilters `json:"filters,omitempty"`
+	Metrics  []string          `json:"metrics"`
+}
+
+// AnalyticsMetric is one computed metric of a report. Value is a number,
+// duration in seconds, percentage, string, resource or table depending on
+// Type.
+type AnalyticsMetric struct {
+	ID    string `json:"id"`
+	Type  string `json:"type"`
+	Value any    `json:"value"`
+}
+
+// AnalyticsReport is an asynchronous analytics report.
+type AnalyticsReport struct {
+	Links    Links             `json:"_links,omitempty"` //nolint:tagliatelle // Front API
+	Status   string            `json:"status"`
+	Progress int               `json:"progress"`
+	Metrics  []AnalyticsMetric `json:"metrics,omitempty"`
+}
+
+// ID returns the report UID from its self link.
+func (r *AnalyticsReport) ID() string {
+	return lastPathSegment(r.Links.Self)
+}
+
+// AnalyticsExportRequest creates an analytics export.
+type AnalyticsExportRequest struct {
+	Start    float64           `json:"start"`
+	End      float64           `json:"end"`
+	Timezone string            `json:"timezone,omitempty"`
+	Filters  *AnalyticsFilters `json:"filters,omitempty"`
+	Type     string            `json:"type"` // messages or events
+	Columns  []string          `json:"columns,omitempty"`
+}
+
+// AnalyticsExport is an asynchronous analytics export. URL is set once the
+// export is done.
+type AnalyticsExport struct {
+	Links     Links   `json:"_links,omitempty"` //nolint:tagliatelle // Front API
+	Status    string  `json:"status"`
+	Progress  int     `json:"progress"`
+	URL       string  `json:"url,omitempty"`
+	Filename  string  `json:"filename,omitempty"`
+	Size      int64   `json:"size,omitempty"`
+	CreatedAt float64 `json:"created_at,omitempty"`
+}
+
+// ID returns the export ID from its self link.
+func (e *AnalyticsExport) ID() string {
+	return lastPathSegment(e.Links.Self)
+}
+
+func lastPathSegment(u string) string {
+	u = strings.TrimRight(u, "/")
+
+	return u[strings.LastIndex(u, "/")+1:]
+}
+
+// CreateAnalyticsReport starts an analytics report.
+func (c *Client) CreateAnalyticsReport(ctx context.Context, req AnalyticsReportRequest) (*AnalyticsReport, error) {
+	var report AnalyticsReport
+	if err := c.Post(ctx, "/analytics/reports", req, &report); err != nil {
+		return nil, err
+	}
+
+	return &report, nil
+}
+
+// GetAnalyticsReport fetches an analytics report and its progress.
+func (c *Client) GetAnalyticsReport(ctx context.Context, id string) (*AnalyticsReport, error) {
+	var report AnalyticsReport
+	if err := c.Get(ctx, "/analytics/reports/"+id, &report); err != nil {
+		return nil, enrichErrorWithContext(err, id, "analytics report")
+	}
+
+	return &report, nil
+}
+
+// CreateAnalyticsExport starts an analytics export.
+func (c *Client) CreateAnalyticsExport(ctx context.Context, req AnalyticsExportRequest) (*AnalyticsExport, error) {
+	var export AnalyticsExport
+	if err := c.Post(ctx, "/analytics/exports", req, &export); err != nil {
+		return nil, err
+	}
+
+	return &export, nil
+}
+
+// GetAnalyticsExport fetches an analytics export and its progress.
+func (c *Client) GetAnalyticsExport(ctx context.Context, id string) (*AnalyticsExport, error) {
+	var export AnalyticsExport
+	if err := c.Get(ctx, "/analytics/exports/"+id, &export); err != nil {
+		return nil, enrichErrorWithContext(err, id, "analytics export")
+	}
+
+	return &export, nil
+}
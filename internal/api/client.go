@@ -44,6 +44,23 @@ type Client struct {
 	httpClient  *http.Client
 	tokenSource oauth2.TokenSource
 	rateLimiter *RateLimiter
+	cache       MetadataCache
+}
+
+// MetadataCache persists slow-changing list responses between runs.
+type MetadataCache interface {
+	// Load decodes the entry for key into out and reports whether it was fresh.
+	Load(key string, out any) bool
+	Store(key string, v any) error
+	Delete(key string) error
+}
+
+// cachedCollections maps cacheable list endpoints to their cache keys.
+var cachedCollections = map[string]string{
+	"/tags":      "tags",
+	"/inboxes":   "inboxes",
+	"/teammates": "teammates",
+	"/channels":  "channels",
 }
 
 // NewClient creates a new API client with the given token source.
@@ -68,6 +85,11 @@ func NewClientWithBaseURL(ts oauth2.TokenSource, baseURL string) *Client {
 	return client
 }
 
+// NewClientWithToken creates a client authenticating with a static API token.
+func NewClientWithToken(token string) *Client {
+	return NewClient(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token}))
+}
+
 // NewClientFromAuth creates a client using stored auth credentials.
 func NewClientFromAuth(clientName, email string) (*Client, error) {
 	store, err := auth.OpenDefault()
@@ -80,9 +102,78 @@ func NewClientFromAuth(clientName, email string) (*Client, error) {
 	return NewClient(ts), nil
 }
 
+// RateLimiter returns the limiter tracking Front's rate limit headers.
+func (c *Client) RateLimiter() *RateLimiter {
+	return c.rateLimiter
+}
+
+// SetMetadataCache makes the tag, inbox, teammate and channel list methods
+// serve from mc while its entries are fresh.
+func (c *Client) SetMetadataCache(mc MetadataCache) {
+	c.cache = mc
+}
+
+// listCached fetches every page of one of the cachedCollections, so callers
+// resolving names see the whole collection. Cache write failures are
+// ignored; the cache is only an optimisation.
+func listCached[T any](ctx context.Context, c *Client, path string) (*ListResponse[T], error) {
+	key := cachedCollections[path]
+	resp := &ListResponse[T]{Results: make([]T, 0)}
+
+	if c.cache != nil && key != "" && c.cache.Load(key, resp) {
+		return resp, nil
+	}
+
+	_, err := Paginate(ctx, c, path, PageOptions{}, func(items []T) error {
+		resp.Results = append(resp.Results, items...)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if c.cache != nil && key != "" {
+		_ = c.cache.Store(key, resp)
+	}
+
+	return resp, nil
+}
+
+// invalidateCache drops the cached collection a write to path may change.
+// Writes to message, draft and conversation sub-resources (e.g. sending
+// through a channel) leave the collection untouched.
+func (c *Client) invalidateCache(path string) {
+	if c.cache == nil {
+		return
+	}
+
+	base, _, _ := strings.Cut(path, "?")
+	switch base[strings.LastIndex(base, "/")+1:] {
+	case "messages", "drafts", "conversations":
+		return
+	}
+
+	for prefix, key := range cachedCollections {
+		if base == prefix || strings.HasPrefix(base, prefix+"/") {
+			_ = c.cache.Delete(key)
+		}
+	}
+}
+
 func (c *Client) do(ctx context.Context, method, path string, body []byte, out interface{}) error {
+	return c.send(ctx, method, path, body, ContentType, out)
+}
+
+// send performs a request with a body of the given content type, retrying
+// once with a fresh token on 401.
+func (c *Client) send(ctx context.Context, method, path string, body []byte, contentType string, out interface{}) error {
 	reqURL := c.baseURL + path
 
+	if method != http.MethodGet {
+		defer c.invalidateCache(path)
+	}
+
 	for attempt := 0; attempt < 2; attempt++ {
 		if c.rateLimiter != nil {
 			if err := c.rateLimiter.Wait(ctx); err != nil {
@@ -111,7 +202,7 @@ func (c *Client) do(ctx context.Context, method, path string, body []byte, out i
 		req.Header.Set("Accept", ContentType)
 
 		if body != nil {
-			req.Header.Set("Content-Type", ContentType)
+			req.Header.Set("Content-Type", contentType)
 		}
 
 		resp, err := c.httpClient.Do(req)
@@ -237,12 +328,20 @@ func (c *Client) Delete(ctx context.Context, path string) error {
 }
 
 // Download performs a GET request and writes the response body to the writer.
+// path may also be an absolute URL, such as a signed export link; the token
+// is only sent when it points at the API host.
 func (c *Client) Download(ctx context.Context, path string, w io.Writer) error {
 	if w == nil {
 		return errWriterRequired
 	}
 
 	reqURL := c.baseURL + path
+	withToken := true
+
+	if strings.HasPrefix(path, "https://") || strings.HasPrefix(path, "http://") {
+		reqURL = path
+		withToken = sameHost(path, c.baseURL)
+	}
 
 	for attempt := 0; attempt < 2; attempt++ {
 		if c.rateLimiter != nil {
@@ -256,12 +355,15 @@ func (c *Client) Download(ctx context.Context, path string, w io.Writer) error {
 			return fmt.Errorf("create request: %w", err)
 		}
 
-		tok, err := c.tokenSource.Token()
-		if err != nil {
-			return &AuthError{Err: err}
+		if withToken {
+			tok, err := c.tokenSource.Token()
+			if err != nil {
+				return &AuthError{Err: err}
+			}
+
+			req.Header.Set("Authorization", "Bearer "+tok.AccessToken)
 		}
 
-		req.Header.Set("Authorization", "Bearer "+tok.AccessToken)
 		req.Header.Set("User-Agent", UserAgent)
 
 		resp, err := c.httpClient.Do(req)
@@ -273,7 +375,7 @@ func (c *Client) Download(ctx context.Context, path string, w io.Writer) error {
 			c.rateLimiter.UpdateFromHeaders(resp.Header)
 		}
 
-		if resp.StatusCode == http.StatusUnauthorized {
+		if resp.StatusCode == http.StatusUnauthorized && withToken {
 			if ts, ok := c.tokenSource.(*auth.TokenSource); ok {
 				ts.Invalidate()
 			}
@@ -320,6 +422,21 @@ func (c *Client) Download(ctx context.Context, path string, w io.Writer) error {
 	}
 }
 
+// sameHost reports whether two absolute URLs share scheme and host.
+func sameHost(a, b string) bool {
+	ua, err := url.Parse(a)
+	if err != nil {
+		return false
+	}
+
+	ub, err := url.Parse(b)
+	if err != nil {
+		return false
+	}
+
+	return ua.Scheme == ub.Scheme && strings.EqualFold(ua.Host, ub.Host)
+}
+
 // Me returns the authenticated user's info.
 func (c *Client) Me(ctx context.Context) (*Me, error) {
 	var me Me
@@ -379,12 +496,7 @@ func (c *Client) GetMessage(ctx context.Context, id string) (*Message, error) {
 
 // ListInboxes lists all inboxes.
 func (c *Client) ListInboxes(ctx context.Context) (*ListResponse[Inbox], error) {
-	var resp ListResponse[Inbox]
-	if err := c.Get(ctx, "/inboxes", &resp); err != nil {
-		return nil, err
-	}
-
-	return &resp, nil
+	return listCached[Inbox](ctx, c, "/inboxes")
 }
 
 // GetInbox gets a single inbox by ID.
@@ -399,12 +511,7 @@ func (c *Client) GetInbox(ctx context.Context, id string) (*Inbox, error) {
 
 // ListTags lists all tags.
 func (c *Client) ListTags(ctx context.Context) (*ListResponse[Tag], error) {
-	var resp ListResponse[Tag]
-	if err := c.Get(ctx, "/tags", &resp); err != nil {
-		return nil, err
-	}
-
-	return &resp, nil
+	return listCached[Tag](ctx, c, "/tags")
 }
 
 // GetTag gets a single tag by ID.
@@ -419,12 +526,7 @@ func (c *Client) GetTag(ctx context.Context, id string) (*Tag, error) {
 
 // ListTeammates lists all teammates.
 func (c *Client) ListTeammates(ctx context.Context) (*ListResponse[Teammate], error) {
-	var resp ListResponse[Teammate]
-	if err := c.Get(ctx, "/teammates", &resp); err != nil {
-		return nil, err
-	}
-
-	return &resp, nil
+	return listCached[Teammate](ctx, c, "/teammates")
 }
 
 // GetTeammate gets a single teammate by ID.
@@ -439,12 +541,7 @@ func (c *Client) GetTeammate(ctx context.Context, id string) (*Teammate, error)
 
 // ListChannels lists all channels.
 func (c *Client) ListChannels(ctx context.Context) (*ListResponse[Channel], error) {
-	var resp ListResponse[Channel]
-	if err := c.Get(ctx, "/channels", &resp); err != nil {
-		return nil, err
-	}
-
-	return &resp, nil
+	return listCached[Channel](ctx, c, "/channels")
 }
 
 // GetChannel gets a single channel by ID.
@@ -472,27 +569,6 @@ func (c *Client) ListContacts(ctx context.Context, limit int) (*ListResponse[Con
 	return &resp, nil
 }
 
-// ListContactsPage fetches a page of contacts using a page token.
-func (c *Client) ListContactsPage(ctx context.Context, pageURL string) (*ListResponse[Contact], error) {
-	// pageURL is a full URL; extract path+query
-	parsed, err := url.Parse(pageURL)
-	if err != nil {
-		return nil, fmt.Errorf("parse page URL: %w", err)
-	}
-
-	path := parsed.Path
-	if parsed.RawQuery != "" {
-		path += "?" + parsed.RawQuery
-	}
-
-	var resp ListResponse[Contact]
-	if err := c.Get(ctx, path, &resp); err != nil {
-		return nil, err
-	}
-
-	return &resp, nil
-}
-
 // GetContact gets a single contact by ID.
 func (c *Client) GetContact(ctx context.Context, id string) (*Contact, error) {
 	var contact Contact
@@ -510,6 +586,7 @@ type ListConversationsOptions struct {
 	Statuses  []string // assigned, unassigned, archived, trashed, snoozed
 	Limit     int
 	PageToken string
+	SortBy    string // date, priority (default: date when SortOrder is set)
 	SortOrder string // asc, desc (default: desc = most recent first)
 }
 
@@ -550,10 +627,19 @@ func (o ListConversationsOptions) Query() string {
 		params.Set("page_token", o.PageToken)
 	}
 
+	sortBy := o.SortBy
+
 	if o.SortOrder != "" && o.SortOrder != "-" {
-		params.Set("sort_by", "date")
+		if sortBy == "" {
+			sortBy = "date"
+		}
+
 		params.Set("sort_order", o.SortOrder)
 	}
 
+	if sortBy != "" {
+		params.Set("sort_by", sortBy)
+	}
+
 	return params.Encode()
 }
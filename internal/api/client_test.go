@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"sync"
@@ -58,3 +59,128 @@ func TestClientRetriesOn401(t *testing.T) {
 		t.Fatalf("expected 2 requests, got %d", requests)
 	}
 }
+
+type memCache map[string]any
+
+func (m memCache) Load(key string, out any) bool {
+	v, ok := m[key]
+	if !ok {
+		return false
+	}
+
+	*out.(*ListResponse[Tag]) = *v.(*ListResponse[Tag])
+
+	return true
+}
+
+func (m memCache) Store(key string, v any) error {
+	m[key] = v
+
+	return nil
+}
+
+func (m memCache) Delete(key string) error {
+	delete(m, key)
+
+	return nil
+}
+
+func TestListTagsUsesMetadataCache(t *testing.T) {
+	var gets int
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			gets++
+		}
+
+		_, _ = w.Write([]byte(`{"_results":[{"id":"tag_1","name":"a"}]}`))
+	}))
+	defer srv.Close()
+
+	client := NewClientWithBaseURL(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}), srv.URL)
+	client.SetMetadataCache(memCache{})
+
+	for range 2 {
+		if _, err := client.ListTags(context.Background()); err != nil {
+			t.Fatalf("ListTags: %v", err)
+		}
+	}
+
+	if gets != 1 {
+		t.Fatalf("expected 1 GET, got %d", gets)
+	}
+
+	if err := client.Post(context.Background(), "/tags", map[string]string{"name": "b"}, nil); err != nil {
+		t.Fatalf("Post: %v", err)
+	}
+
+	if _, err := client.ListTags(context.Background()); err != nil {
+		t.Fatalf("ListTags: %v", err)
+	}
+
+	if gets != 2 {
+		t.Fatalf("expected tag creation to invalidate the cache, got %d GETs", gets)
+	}
+}
+
+func TestDownloadOnlySendsTokenToAPIHost(t *testing.T) {
+	auths := make(map[string]string)
+
+	var mu sync.Mutex
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		auths[r.Host] = r.Header.Get("Authorization")
+		mu.Unlock()
+
+		_, _ = w.Write([]byte("data"))
+	})
+
+	apiSrv := httptest.NewServer(handler)
+	defer apiSrv.Close()
+
+	fileSrv := httptest.NewServer(handler)
+	defer fileSrv.Close()
+
+	client := NewClientWithBaseURL(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}), apiSrv.URL)
+
+	for _, path := range []string{"/download/fil_1", fileSrv.URL + "/export.csv"} {
+		if err := client.Download(context.Background(), path, io.Discard); err != nil {
+			t.Fatalf("Download(%s): %v", path, err)
+		}
+	}
+
+	if auths[apiSrv.Listener.Addr().String()] != "Bearer token" {
+		t.Errorf("expected token on API host, got %q", auths[apiSrv.Listener.Addr().String()])
+	}
+
+	if got := auths[fileSrv.Listener.Addr().String()]; got != "" {
+		t.Errorf("expected no token on foreign host, got %q", got)
+	}
+}
+
+func TestListTagsFetchesAllPages(t *testing.T) {
+	var srv *httptest.Server
+
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page_token") == "" {
+			_, _ = w.Write([]byte(`{"_results":[{"id":"tag_1","name":"a"}],"_pagination":{"next":"` + srv.URL + `/tags?page_token=p2"}}`))
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"_results":[{"id":"tag_2","name":"b"}]}`))
+	}))
+	defer srv.Close()
+
+	client := NewClientWithBaseURL(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}), srv.URL)
+
+	resp, err := client.ListTags(context.Background())
+	if err != nil {
+		t.Fatalf("ListTags: %v", err)
+	}
+
+	if len(resp.Results) != 2 || resp.Results[1].ID != "tag_2" {
+		t.Fatalf("expected both pages, got %+v", resp.Results)
+	}
+}
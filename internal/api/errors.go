@@ -13,6 +13,7 @@ const (
 	ExitAuth      = 3
 	ExitNotFound  = 4
 	ExitRateLimit = 5
+	ExitCancelled = 6
 )
 
 var (
@@ -13,6 +13,7 @@ var ResourcePrefixes = map[string]string{
 	"inb_": "inbox",
 	"chn_": "channel",
 	"ctc_": "contact",
+	"crd_": "contact",
 	"acc_": "account",
 	"rul_": "rule",
 	"lnk_": "link",
@@ -0,0 +1,157 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// MaxAttachmentSize is the total size Front accepts for the attachments of
+// a single message.
+const MaxAttachmentSize = 25 << 20
+
+var (
+	errAttachmentTooLarge = errors.New("attachments exceed Front's 25 MB limit")
+	errAttachmentNotFile  = errors.New("not a regular file")
+)
+
+// FileUpload is a local file sent as a message or draft attachment.
+type FileUpload struct {
+	Name        string
+	ContentType string
+	Data        []byte
+}
+
+// LoadAttachments reads the files at paths, checking that together they
+// stay within MaxAttachmentSize, and detects each file's content type.
+func LoadAttachments(paths []string) ([]FileUpload, error) {
+	uploads := make([]FileUpload, 0, len(paths))
+
+	var total int64
+
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("attach %s: %w", path, err)
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil, fmt.Errorf("attach %s: %w", path, errAttachmentNotFile)
+		}
+
+		total += info.Size()
+		if total > MaxAttachmentSize {
+			return nil, fmt.Errorf("attach %s: %w (%s in total)", path, errAttachmentTooLarge, formatSize(total))
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("attach %s: %w", path, err)
+		}
+
+		uploads = append(uploads, FileUpload{
+			Name:        filepath.Base(path),
+			ContentType: detectContentType(path, data),
+			Data:        data,
+		})
+	}
+
+	return uploads, nil
+}
+
+// detectContentType prefers the file extension and falls back to sniffing
+// the first bytes of the content.
+func detectContentType(path string, data []byte) string {
+	if ct := mime.TypeByExtension(strings.ToLower(filepath.Ext(path))); ct != "" {
+		return ct
+	}
+
+	return http.DetectContentType(data)
+}
+
+func formatSize(n int64) string {
+	return strconv.FormatFloat(float64(n)/(1<<20), 'f', 1, 64) + " MB"
+}
+
+// PostMultipart performs a multipart/form-data POST with fields and file
+// attachments, as Front requires for messages and drafts with attachments.
+func (c *Client) PostMultipart(ctx context.Context, path string, fields map[string]any, files []FileUpload, out interface{}) error {
+	body, contentType, err := encodeMultipart(fields, files)
+	if err != nil {
+		return err
+	}
+
+	return c.send(ctx, http.MethodPost, path, body, contentType, out)
+}
+
+// PatchMultipart performs a multipart/form-data PATCH, see PostMultipart.
+func (c *Client) PatchMultipart(ctx context.Context, path string, fields map[string]any, files []FileUpload, out interface{}) error {
+	body, contentType, err := encodeMultipart(fields, files)
+	if err != nil {
+		return err
+	}
+
+	return c.send(ctx, http.MethodPatch, path, body, contentType, out)
+}
+
+// encodeMultipart writes fields the way Front's form endpoints expect:
+// slices as repeated "name[]" parts and files as "attachments[]".
+func encodeMultipart(fields map[string]any, files []FileUpload) ([]byte, string, error) {
+	var buf bytes.Buffer
+
+	w := multipart.NewWriter(&buf)
+
+	for name, value := range fields {
+		var err error
+
+		switch v := value.(type) {
+		case []string:
+			for _, item := range v {
+				if err = w.WriteField(name+"[]", item); err != nil {
+					break
+				}
+			}
+		case string:
+			err = w.WriteField(name, v)
+		default:
+			err = w.WriteField(name, fmt.Sprint(v))
+		}
+
+		if err != nil {
+			return nil, "", fmt.Errorf("write field %s: %w", name, err)
+		}
+	}
+
+	for _, f := range files {
+		h := make(textproto.MIMEHeader)
+		h.Set("Content-Disposition", mime.FormatMediaType("form-data", map[string]string{
+			"name":     "attachments[]",
+			"filename": f.Name,
+		}))
+		h.Set("Content-Type", f.ContentType)
+
+		part, err := w.CreatePart(h)
+		if err != nil {
+			return nil, "", fmt.Errorf("write attachment %s: %w", f.Name, err)
+		}
+
+		if _, err := part.Write(f.Data); err != nil {
+			return nil, "", fmt.Errorf("write attachment %s: %w", f.Name, err)
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, "", fmt.Errorf("close multipart body: %w", err)
+	}
+
+	return buf.Bytes(), w.FormDataContentType(), nil
+}
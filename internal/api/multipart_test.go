@@ -0,0 +1,104 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/oauth2"
+)
+
+func TestLoadAttachments(t *testing.T) {
+	dir := t.TempDir()
+	pdf := filepath.Join(dir, "invoice.pdf")
+	log := filepath.Join(dir, "app")
+
+	if err := os.WriteFile(pdf, []byte("%PDF-1.4"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(log, []byte("plain log line\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := LoadAttachments([]string{pdf, log})
+	if err != nil {
+		t.Fatalf("LoadAttachments: %v", err)
+	}
+
+	if files[0].Name != "invoice.pdf" || files[0].ContentType != "application/pdf" {
+		t.Fatalf("pdf = %+v", files[0])
+	}
+
+	if files[1].ContentType != "text/plain; charset=utf-8" {
+		t.Fatalf("sniffed content type = %q", files[1].ContentType)
+	}
+
+	big := filepath.Join(dir, "big.bin")
+	if err := os.WriteFile(big, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Truncate(big, MaxAttachmentSize+1); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadAttachments([]string{big}); !errors.Is(err, errAttachmentTooLarge) {
+		t.Fatalf("err = %v, want errAttachmentTooLarge", err)
+	}
+}
+
+func TestPostMultipart(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Errorf("parse multipart: %v", err)
+
+			return
+		}
+
+		if got := r.MultipartForm.Value["to[]"]; len(got) != 2 || got[1] != "b@example.com" {
+			t.Errorf("to[] = %v", got)
+		}
+
+		if got := r.FormValue("body"); got != "<p>Hi</p>" {
+			t.Errorf("body = %q", got)
+		}
+
+		fh := r.MultipartForm.File["attachments[]"]
+		if len(fh) != 1 || fh[0].Filename != "a.txt" || fh[0].Header.Get("Content-Type") != "text/plain" {
+			t.Errorf("attachments = %+v", fh)
+
+			return
+		}
+
+		f, _ := fh[0].Open()
+		data, _ := io.ReadAll(f)
+
+		if string(data) != "hello" {
+			t.Errorf("attachment data = %q", data)
+		}
+
+		_, _ = io.WriteString(w, `{"id":"msg_1"}`)
+	}))
+	defer srv.Close()
+
+	client := NewClientWithBaseURL(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "t"}), srv.URL)
+
+	var out Message
+
+	err := client.PostMultipart(context.Background(), "/channels/cha_1/messages",
+		map[string]any{"to": []string{"a@example.com", "b@example.com"}, "body": "<p>Hi</p>"},
+		[]FileUpload{{Name: "a.txt", ContentType: "text/plain", Data: []byte("hello")}}, &out)
+	if err != nil {
+		t.Fatalf("PostMultipart: %v", err)
+	}
+
+	if out.ID != "msg_1" {
+		t.Fatalf("out = %+v", out)
+	}
+}
@@ -0,0 +1,106 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ErrStopPagination can be returned by a Paginate callback to stop fetching
+// further pages without reporting an error.
+var ErrStopPagination = errors.New("stop pagination")
+
+// PageOptions controls how Paginate walks a cursor-paginated endpoint.
+type PageOptions struct {
+	// MaxPages stops after this many pages; 0 means follow every page.
+	MaxPages int
+}
+
+// Paginate fetches path and follows _pagination.next, calling fn with the
+// results of each page as it arrives. It returns the URL of the next page
+// that was not fetched (when MaxPages was reached or fn returned
+// ErrStopPagination), or "" once the listing is exhausted.
+func Paginate[T any](ctx context.Context, c *Client, path string, opts PageOptions, fn func([]T) error) (string, error) {
+	for page := 1; ; page++ {
+		var resp ListResponse[T]
+		if err := c.Get(ctx, path, &resp); err != nil {
+			return "", err
+		}
+
+		next := resp.Pagination.Next
+
+		if err := fn(resp.Results); err != nil {
+			if errors.Is(err, ErrStopPagination) {
+				return next, nil
+			}
+
+			return "", err
+		}
+
+		if next == "" {
+			return "", nil
+		}
+
+		if opts.MaxPages > 0 && page >= opts.MaxPages {
+			return next, nil
+		}
+
+		nextPath, err := PagePath(next)
+		if err != nil {
+			return "", err
+		}
+
+		path = nextPath
+	}
+}
+
+// PagePath converts a pagination URL into a path+query usable with Client.Get.
+func PagePath(pageURL string) (string, error) {
+	parsed, err := url.Parse(pageURL)
+	if err != nil {
+		return "", fmt.Errorf("parse page URL: %w", err)
+	}
+
+	path := parsed.Path
+	if parsed.RawQuery != "" {
+		path += "?" + parsed.RawQuery
+	}
+
+	return path, nil
+}
+
+// PageToken extracts the page_token parameter from a pagination URL.
+// Returns the input unchanged if it is not a URL with a page_token.
+func PageToken(pageURL string) string {
+	parsed, err := url.Parse(pageURL)
+	if err != nil {
+		return pageURL
+	}
+
+	if token := parsed.Query().Get("page_token"); token != "" {
+		return token
+	}
+
+	return pageURL
+}
+
+// WithPageToken sets the page_token query parameter on a path.
+func WithPageToken(path, token string) string {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return path
+	}
+
+	base, rawQuery, _ := strings.Cut(path, "?")
+
+	params, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		params = url.Values{}
+	}
+
+	params.Set("page_token", PageToken(token))
+
+	return base + "?" + params.Encode()
+}
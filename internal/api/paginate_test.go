@@ -0,0 +1,96 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"golang.org/x/oauth2"
+)
+
+func newPagedServer(t *testing.T, pages int) *httptest.Server {
+	t.Helper()
+
+	var srv *httptest.Server
+
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := 1
+		if token := r.URL.Query().Get("page_token"); token != "" {
+			_, _ = fmt.Sscanf(token, "p%d", &page)
+		}
+
+		next := ""
+		if page < pages {
+			next = fmt.Sprintf(`%s/items?limit=1&page_token=p%d`, srv.URL, page+1)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"_results":[{"id":"item_%d"}],"_pagination":{"next":%q}}`, page, next)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+type pagedItem struct {
+	ID string `json:"id"`
+}
+
+func TestPaginateFollowsNext(t *testing.T) {
+	srv := newPagedServer(t, 3)
+	client := NewClientWithBaseURL(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}), srv.URL)
+
+	var ids []string
+
+	next, err := Paginate(context.Background(), client, "/items?limit=1", PageOptions{}, func(items []pagedItem) error {
+		for _, item := range items {
+			ids = append(ids, item.ID)
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Paginate: %v", err)
+	}
+
+	if next != "" {
+		t.Fatalf("expected no next page, got %q", next)
+	}
+
+	if len(ids) != 3 || ids[2] != "item_3" {
+		t.Fatalf("unexpected ids: %v", ids)
+	}
+}
+
+func TestPaginateStopsAtMaxPages(t *testing.T) {
+	srv := newPagedServer(t, 5)
+	client := NewClientWithBaseURL(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}), srv.URL)
+
+	count := 0
+
+	next, err := Paginate(context.Background(), client, "/items", PageOptions{MaxPages: 2}, func(items []pagedItem) error {
+		count += len(items)
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Paginate: %v", err)
+	}
+
+	if count != 2 {
+		t.Fatalf("expected 2 items, got %d", count)
+	}
+
+	if got := PageToken(next); got != "p3" {
+		t.Fatalf("expected resume token p3, got %q", got)
+	}
+}
+
+func TestWithPageTokenAcceptsNextURL(t *testing.T) {
+	got := WithPageToken("/conversations?limit=25", "https://api2.frontapp.com/conversations?page_token=abc")
+	if got != "/conversations?limit=25&page_token=abc" {
+		t.Fatalf("unexpected path: %s", got)
+	}
+}
@@ -16,6 +16,17 @@ type RateLimiter struct {
 	burstLimit     int
 	burstRemaining int
 	resetAt        time.Time
+	nextAt         time.Time // start of the next free request slot
+	statePath      string    // shared state file, see Share
+}
+
+// rateState is a snapshot of Front's rate limit headers.
+type rateState struct {
+	Limit          int       `json:"limit"`
+	Remaining      int       `json:"remaining"`
+	BurstLimit     int       `json:"burst_limit"`
+	BurstRemaining int       `json:"burst_remaining"`
+	ResetAt        time.Time `json:"reset_at"`
 }
 
 func NewRateLimiter() *RateLimiter {
@@ -24,7 +35,6 @@ func NewRateLimiter() *RateLimiter {
 
 func (r *RateLimiter) UpdateFromHeaders(h http.Header) {
 	r.mu.Lock()
-	defer r.mu.Unlock()
 
 	r.limit = headerInt(h, "x-ratelimit-limit", r.limit)
 	r.remaining = headerInt(h, "x-ratelimit-remaining", r.remaining)
@@ -38,50 +48,112 @@ func (r *RateLimiter) UpdateFromHeaders(h http.Header) {
 			r.resetAt = parsed
 		}
 	}
-}
 
-func (r *RateLimiter) Wait(ctx context.Context) error {
-	r.mu.Lock()
-	limit := r.limit
-	remaining := r.remaining
-	burstRemaining := r.burstRemaining
-	resetAt := r.resetAt
+	path := r.statePath
+	state := r.snapshot()
 	r.mu.Unlock()
 
-	if limit <= 0 || resetAt.IsZero() {
-		return nil
+	// Publishing takes a file lock, so it happens outside r.mu to keep
+	// other goroutines' Wait and UpdateFromHeaders calls from queuing up.
+	if path != "" {
+		r.publish(path, state)
+	}
+}
+
+// snapshot returns the limiter's state. The caller must hold r.mu.
+func (r *RateLimiter) snapshot() rateState {
+	return rateState{
+		Limit:          r.limit,
+		Remaining:      r.remaining,
+		BurstLimit:     r.burstLimit,
+		BurstRemaining: r.burstRemaining,
+		ResetAt:        r.resetAt,
+	}
+}
+
+// pace returns how far apart requests should be spread from now so the
+// remaining budget lasts until the reset, and whether the budget is spent,
+// in which case callers wait for ResetAt instead.
+func (s rateState) pace(now time.Time) (time.Duration, bool) {
+	if s.Limit <= 0 || s.ResetAt.IsZero() {
+		return 0, false
 	}
 
 	extra := 0
 
-	if burstRemaining > 0 {
-		maxExtra := limit / 2
-		if burstRemaining < maxExtra {
-			extra = burstRemaining
+	if s.BurstRemaining > 0 {
+		maxExtra := s.Limit / 2
+		if s.BurstRemaining < maxExtra {
+			extra = s.BurstRemaining
 		} else {
 			extra = maxExtra
 		}
 	}
 
-	effectiveRemaining := remaining + extra
+	effectiveRemaining := s.Remaining + extra
 	if effectiveRemaining <= 1 {
-		return sleepUntil(ctx, resetAt)
+		return 0, true
 	}
 
-	interval := time.Until(resetAt) / time.Duration(effectiveRemaining)
-	if interval <= 0 {
-		return nil
+	return s.ResetAt.Sub(now) / time.Duration(effectiveRemaining), false
+}
+
+func (r *RateLimiter) Wait(ctx context.Context) error {
+	if delay, ok := r.reserveShared(time.Now()); ok {
+		return sleepUntil(ctx, time.Now().Add(delay))
 	}
 
-	timer := time.NewTimer(interval)
-	defer timer.Stop()
+	// Hand out request slots one interval apart, so concurrent callers
+	// share the pace instead of each waiting one interval.
+	r.mu.Lock()
+	now := time.Now()
+
+	interval, exhausted := r.snapshot().pace(now)
+	if !exhausted && interval <= 0 {
+		r.mu.Unlock()
 
-	select {
-	case <-timer.C:
 		return nil
-	case <-ctx.Done():
-		return fmt.Errorf("rate limit wait interrupted: %w", ctx.Err())
 	}
+
+	start := now
+	if exhausted && r.resetAt.After(start) {
+		start = r.resetAt
+	}
+
+	if r.nextAt.After(start) {
+		start = r.nextAt
+	}
+
+	r.nextAt = start.Add(interval)
+	r.mu.Unlock()
+
+	return sleepUntil(ctx, start)
+}
+
+// PollInterval suggests how long a poller should wait between requests so
+// that it consumes at most share (0-1] of the per-minute request budget
+// reported by Front. It never returns less than floor, and waits for the
+// window to reset when the remaining budget is nearly exhausted.
+func (r *RateLimiter) PollInterval(floor time.Duration, share float64) time.Duration {
+	r.mu.Lock()
+	limit := r.limit
+	remaining := r.remaining
+	resetAt := r.resetAt
+	r.mu.Unlock()
+
+	if limit <= 0 || share <= 0 {
+		return floor
+	}
+
+	interval := time.Duration(float64(time.Minute) / (float64(limit) * share))
+
+	if remaining <= limit/10 {
+		if untilReset := time.Until(resetAt); untilReset > interval {
+			interval = untilReset
+		}
+	}
+
+	return max(interval, floor)
 }
 
 func sleepUntil(ctx context.Context, t time.Time) error {
@@ -0,0 +1,154 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/gofrs/flock"
+)
+
+// sharedLockTimeout bounds how long a process waits for the shared state
+// lock before falling back to its own state.
+const sharedLockTimeout = 5 * time.Second
+
+var errSharedLock = errors.New("rate limit state is locked")
+
+// sharedRateState is the rate limit state file shared by the processes of
+// one account.
+type sharedRateState struct {
+	rateState
+	// NextAt is when the next request may start. Each reservation pushes it
+	// one pacing interval further, so parallel processes take turns.
+	NextAt time.Time `json:"next_at"`
+}
+
+// Share makes the limiter coordinate with other processes through the state
+// file at path. Responses publish Front's rate limit headers to it and Wait
+// reserves the next request slot from it, so parallel invocations pace
+// themselves against the company-wide budget together. When the file can't
+// be used the limiter falls back to its own state.
+func (r *RateLimiter) Share(path string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.statePath = path
+}
+
+// merge folds headers seen by this process into the shared state.
+func (s *sharedRateState) merge(local rateState, now time.Time) {
+	if local.Limit <= 0 || local.ResetAt.IsZero() {
+		return
+	}
+
+	if !now.Before(s.ResetAt) || local.ResetAt.After(s.ResetAt) {
+		s.rateState = local
+
+		return
+	}
+
+	// Same window: the lowest count is the most recent.
+	s.Remaining = min(s.Remaining, local.Remaining)
+	s.BurstRemaining = min(s.BurstRemaining, local.BurstRemaining)
+}
+
+// publish merges state into the file at path. Failures are ignored; the
+// shared state is only an optimisation.
+func (r *RateLimiter) publish(path string, state rateState) {
+	_ = updateSharedState(path, func(s *sharedRateState) bool {
+		s.merge(state, time.Now())
+
+		return true
+	})
+}
+
+// reserveShared claims the next request slot from the shared state and
+// returns how long to wait for it. ok is false when the limiter isn't shared
+// or the state file is unusable.
+func (r *RateLimiter) reserveShared(now time.Time) (delay time.Duration, ok bool) {
+	r.mu.Lock()
+	path := r.statePath
+	local := r.snapshot()
+	r.mu.Unlock()
+
+	if path == "" {
+		return 0, false
+	}
+
+	err := updateSharedState(path, func(s *sharedRateState) bool {
+		s.merge(local, now)
+
+		if s.Limit <= 0 || !now.Before(s.ResetAt) {
+			// Nothing known about the current window yet.
+			return false
+		}
+
+		start := now
+		if s.NextAt.After(start) {
+			start = s.NextAt
+		}
+
+		interval, exhausted := s.pace(now)
+		if exhausted && s.ResetAt.After(start) {
+			start = s.ResetAt
+		}
+
+		s.NextAt = start.Add(interval)
+		s.Remaining = max(s.Remaining-1, 0)
+		delay = start.Sub(now)
+
+		return true
+	})
+	if err != nil {
+		return 0, false
+	}
+
+	return delay, true
+}
+
+// updateSharedState runs fn on the state at path under its file lock and
+// writes the state back when fn returns true.
+func updateSharedState(path string, fn func(*sharedRateState) bool) error {
+	lock := flock.New(path + ".lock")
+
+	ctx, cancel := context.WithTimeout(context.Background(), sharedLockTimeout)
+	defer cancel()
+
+	locked, err := lock.TryLockContext(ctx, 10*time.Millisecond)
+	if err != nil || !locked {
+		return errors.Join(errSharedLock, err)
+	}
+
+	defer func() { _ = lock.Unlock() }()
+
+	var state sharedRateState
+
+	// A missing or corrupt file starts over from this process's state.
+	if b, err := os.ReadFile(path); err == nil { //nolint:gosec // state file path
+		_ = json.Unmarshal(b, &state)
+	}
+
+	if !fn(&state) {
+		return nil
+	}
+
+	b, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("encode rate limit state: %w", err)
+	}
+
+	tmp := path + ".tmp"
+
+	if err := os.WriteFile(tmp, b, 0o600); err != nil {
+		return fmt.Errorf("write rate limit state: %w", err)
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("commit rate limit state: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package api
+
+import (
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestSharedRateLimiterPacesProcessesTogether(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ratelimit.json")
+	reset := time.Now().Add(60 * time.Second).Truncate(time.Second)
+
+	headers := func(remaining int) http.Header {
+		h := http.Header{}
+		h.Set("x-ratelimit-limit", "100")
+		h.Set("x-ratelimit-remaining", strconv.Itoa(remaining))
+		h.Set("x-ratelimit-reset", strconv.FormatInt(reset.Unix(), 10))
+
+		return h
+	}
+
+	a, b := NewRateLimiter(), NewRateLimiter()
+	a.Share(path)
+	b.Share(path)
+
+	// Only a has seen a response; b learns the budget from the shared file.
+	a.UpdateFromHeaders(headers(10))
+
+	now := time.Now()
+
+	first, ok := b.reserveShared(now)
+	if !ok || first != 0 {
+		t.Fatalf("first reservation: delay %v ok %v", first, ok)
+	}
+
+	second, ok := a.reserveShared(now)
+	if !ok || second < 4*time.Second || second > 7*time.Second {
+		t.Fatalf("second reservation should wait one interval (~6s), got %v", second)
+	}
+
+	// A spent budget makes every process wait for the reset.
+	b.UpdateFromHeaders(headers(0))
+
+	third, ok := a.reserveShared(now)
+	if !ok || third < reset.Sub(now)-time.Second {
+		t.Fatalf("exhausted budget should wait for the reset, got %v", third)
+	}
+}
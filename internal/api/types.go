@@ -198,6 +198,16 @@ type ListResponse[T any] struct {
 	Links      Links      `json:"_links,omitempty"`      //nolint:tagliatelle // Front API
 }
 
+// ResultItems returns the results as a slice of any, for per-item output.
+func (r ListResponse[T]) ResultItems() []any {
+	items := make([]any, len(r.Results))
+	for i := range r.Results {
+		items[i] = r.Results[i]
+	}
+
+	return items
+}
+
 // Me represents the authenticated user.
 type Me struct {
 	ID          string `json:"id"`
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/99designs/keyring"
+	"golang.org/x/oauth2"
 	"golang.org/x/term"
 
 	"github.com/dedene/frontapp-cli/internal/config"
@@ -24,6 +25,13 @@ type Store interface {
 	ListTokens() ([]Token, error)
 }
 
+// AccessTokenStore caches access tokens so separate processes can share
+// them until they expire. KeyringStore implements it.
+type AccessTokenStore interface {
+	GetAccessToken(client, email string) (*oauth2.Token, error)
+	SetAccessToken(client, email string, tok *oauth2.Token) error
+}
+
 type KeyringStore struct {
 	ring keyring.Keyring
 }
@@ -34,6 +42,8 @@ type Token struct {
 	Scopes       []string  `json:"scopes,omitempty"`
 	CreatedAt    time.Time `json:"created_at,omitempty"`
 	RefreshToken string    `json:"-"`
+	// APIToken is a static Front API token used instead of OAuth.
+	APIToken string `json:"-"`
 }
 
 const (
@@ -43,7 +53,7 @@ const (
 
 var (
 	errMissingEmail        = errors.New("missing email")
-	errMissingRefreshToken = errors.New("missing refresh token")
+	errMissingRefreshToken = errors.New("missing refresh token or API token")
 	errNoTTY               = errors.New("no TTY available for keyring password prompt")
 	errInvalidBackend      = errors.New("invalid keyring backend")
 	errKeyringTimeout      = errors.New("keyring connection timed out")
@@ -185,11 +195,17 @@ func ResetDefaultStore() {
 }
 
 type storedToken struct {
-	RefreshToken string    `json:"refresh_token"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	APIToken     string    `json:"api_token,omitempty"`
 	Scopes       []string  `json:"scopes,omitempty"`
 	CreatedAt    time.Time `json:"created_at,omitempty"`
 }
 
+type storedAccessToken struct {
+	AccessToken string    `json:"access_token"`
+	Expiry      time.Time `json:"expiry"`
+}
+
 func (s *KeyringStore) Keys() ([]string, error) {
 	keys, err := s.ring.Keys()
 	if err != nil {
@@ -205,7 +221,7 @@ func (s *KeyringStore) SetToken(client, email string, tok Token) error {
 		return errMissingEmail
 	}
 
-	if tok.RefreshToken == "" {
+	if tok.RefreshToken == "" && tok.APIToken == "" {
 		return errMissingRefreshToken
 	}
 
@@ -220,6 +236,7 @@ func (s *KeyringStore) SetToken(client, email string, tok Token) error {
 
 	payload, err := json.Marshal(storedToken{
 		RefreshToken: tok.RefreshToken,
+		APIToken:     tok.APIToken,
 		Scopes:       tok.Scopes,
 		CreatedAt:    tok.CreatedAt,
 	})
@@ -264,6 +281,7 @@ func (s *KeyringStore) GetToken(client, email string) (Token, error) {
 		Scopes:       st.Scopes,
 		CreatedAt:    st.CreatedAt,
 		RefreshToken: st.RefreshToken,
+		APIToken:     st.APIToken,
 	}, nil
 }
 
@@ -282,6 +300,60 @@ func (s *KeyringStore) DeleteToken(client, email string) error {
 		return fmt.Errorf("delete token: %w", err)
 	}
 
+	if err := s.ring.Remove(accessTokenKey(normalizedClient, email)); err != nil && !errors.Is(err, keyring.ErrKeyNotFound) {
+		return fmt.Errorf("delete access token: %w", err)
+	}
+
+	return nil
+}
+
+func (s *KeyringStore) GetAccessToken(client, email string) (*oauth2.Token, error) {
+	email = normalize(email)
+	if email == "" {
+		return nil, errMissingEmail
+	}
+
+	normalizedClient, err := config.NormalizeClientNameOrDefault(client)
+	if err != nil {
+		return nil, fmt.Errorf("normalize client: %w", err)
+	}
+
+	item, err := s.ring.Get(accessTokenKey(normalizedClient, email))
+	if err != nil {
+		return nil, fmt.Errorf("read access token: %w", err)
+	}
+
+	var st storedAccessToken
+	if err := json.Unmarshal(item.Data, &st); err != nil {
+		return nil, fmt.Errorf("decode access token: %w", err)
+	}
+
+	return &oauth2.Token{AccessToken: st.AccessToken, Expiry: st.Expiry}, nil
+}
+
+func (s *KeyringStore) SetAccessToken(client, email string, tok *oauth2.Token) error {
+	email = normalize(email)
+	if email == "" {
+		return errMissingEmail
+	}
+
+	normalizedClient, err := config.NormalizeClientNameOrDefault(client)
+	if err != nil {
+		return fmt.Errorf("normalize client: %w", err)
+	}
+
+	payload, err := json.Marshal(storedAccessToken{AccessToken: tok.AccessToken, Expiry: tok.Expiry})
+	if err != nil {
+		return fmt.Errorf("encode access token: %w", err)
+	}
+
+	if err := s.ring.Set(keyring.Item{
+		Key:  accessTokenKey(normalizedClient, email),
+		Data: payload,
+	}); err != nil {
+		return wrapKeychainError(fmt.Errorf("store access token: %w", err))
+	}
+
 	return nil
 }
 
@@ -349,6 +421,10 @@ func tokenKey(client, email string) string {
 	return fmt.Sprintf("token:%s:%s", client, email)
 }
 
+func accessTokenKey(client, email string) string {
+	return fmt.Sprintf("access:%s:%s", client, email)
+}
+
 func normalize(s string) string {
 	return strings.ToLower(strings.TrimSpace(s))
 }
@@ -31,12 +31,22 @@ var frontEndpoint = oauth2.Endpoint{
 const defaultCallbackPort = 8484
 
 type AuthorizeOptions struct {
-	Manual       bool
+	Manual bool
+	// Headless waits for the callback without opening a browser and prints
+	// port-forwarding instructions instead, for logins over SSH.
+	Headless bool
+	// PKCE adds an S256 code challenge. It is always used for clients
+	// without a secret.
+	PKCE         bool
 	ForceConsent bool
 	Timeout      time.Duration
 	Client       string
 }
 
+// headlessReminder is how often a headless login reminds the user it is
+// still waiting.
+const headlessReminder = 30 * time.Second
+
 var (
 	errAuthorization       = errors.New("authorization error")
 	errHTTPSRequired       = errors.New("redirect uri must use https")
@@ -93,14 +103,22 @@ func Authorize(ctx context.Context, opts AuthorizeOptions) (string, error) {
 		authOpts = append(authOpts, oauth2.SetAuthURLParam("prompt", "consent"))
 	}
 
+	var exchangeOpts []oauth2.AuthCodeOption
+
+	if opts.PKCE || creds.ClientSecret == "" {
+		verifier := oauth2.GenerateVerifier()
+		authOpts = append(authOpts, oauth2.S256ChallengeOption(verifier))
+		exchangeOpts = append(exchangeOpts, oauth2.VerifierOption(verifier))
+	}
+
 	if opts.Manual {
-		return authorizeManual(ctx, cfg, state, authOpts)
+		return authorizeManual(ctx, cfg, state, authOpts, exchangeOpts)
 	}
 
-	return authorizeWithServer(ctx, cfg, state, authOpts)
+	return authorizeWithServer(ctx, cfg, state, authOpts, exchangeOpts, opts.Headless)
 }
 
-func authorizeManual(ctx context.Context, cfg oauth2.Config, state string, authOpts []oauth2.AuthCodeOption) (string, error) {
+func authorizeManual(ctx context.Context, cfg oauth2.Config, state string, authOpts, exchangeOpts []oauth2.AuthCodeOption) (string, error) {
 	authURL := cfg.AuthCodeURL(state, authOpts...)
 
 	fmt.Fprintln(os.Stderr, "Visit this URL to authorize:")
@@ -127,7 +145,7 @@ func authorizeManual(ctx context.Context, cfg oauth2.Config, state string, authO
 		return "", errStateMismatch
 	}
 
-	tok, err := cfg.Exchange(ctx, code)
+	tok, err := cfg.Exchange(ctx, code, exchangeOpts...)
 	if err != nil {
 		return "", fmt.Errorf("exchange code: %w", err)
 	}
@@ -139,7 +157,9 @@ func authorizeManual(ctx context.Context, cfg oauth2.Config, state string, authO
 	return tok.RefreshToken, nil
 }
 
-func authorizeWithServer(ctx context.Context, cfg oauth2.Config, state string, authOpts []oauth2.AuthCodeOption) (string, error) {
+func authorizeWithServer(
+	ctx context.Context, cfg oauth2.Config, state string, authOpts, exchangeOpts []oauth2.AuthCodeOption, headless bool,
+) (string, error) {
 	// Parse port from redirect URI
 	parsed, err := url.Parse(cfg.RedirectURL)
 	if err != nil {
@@ -244,44 +264,100 @@ func authorizeWithServer(ctx context.Context, cfg oauth2.Config, state string, a
 
 	authURL := cfg.AuthCodeURL(state, authOpts...)
 
-	fmt.Fprintln(os.Stderr, "Opening browser for authorization...")
-	fmt.Fprintln(os.Stderr, "If the browser doesn't open, visit:")
-	fmt.Fprintln(os.Stderr, authURL)
-	_ = openBrowserFn(authURL)
+	// A nil channel never fires, so only headless logins get reminders.
+	var remind <-chan time.Time
+
+	if headless {
+		printHeadlessInstructions(port, authURL, timeLeft(ctx))
+
+		ticker := time.NewTicker(headlessReminder)
+		defer ticker.Stop()
+
+		remind = ticker.C
+	} else {
+		fmt.Fprintln(os.Stderr, "Opening browser for authorization...")
+		fmt.Fprintln(os.Stderr, "If the browser doesn't open, visit:")
+		fmt.Fprintln(os.Stderr, authURL)
+		_ = openBrowserFn(authURL)
+	}
 
-	select {
-	case code := <-codeCh:
-		fmt.Fprintln(os.Stderr, "Authorization received. Finishing...")
+	for {
+		select {
+		case <-remind:
+			fmt.Fprintf(os.Stderr, "Still waiting for the callback on port %s (%s left)...\n", port, timeLeft(ctx))
 
-		tok, err := cfg.Exchange(ctx, code)
-		if err != nil {
+		case code := <-codeCh:
+			return finishServerAuthorization(ctx, srv, cfg, code, exchangeOpts)
+
+		case err := <-errCh:
 			_ = srv.Close()
 
-			return "", fmt.Errorf("exchange code: %w", err)
-		}
+			return "", err
 
-		if tok.RefreshToken == "" {
+		case <-ctx.Done():
 			_ = srv.Close()
 
-			return "", errNoRefreshToken
+			return "", fmt.Errorf("authorization canceled: %w", ctx.Err())
 		}
+	}
+}
 
-		shutdownCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
-		defer cancel()
-		_ = srv.Shutdown(shutdownCtx)
-
-		return tok.RefreshToken, nil
+func finishServerAuthorization(
+	ctx context.Context, srv *http.Server, cfg oauth2.Config, code string, exchangeOpts []oauth2.AuthCodeOption,
+) (string, error) {
+	fmt.Fprintln(os.Stderr, "Authorization received. Finishing...")
 
-	case err := <-errCh:
+	tok, err := cfg.Exchange(ctx, code, exchangeOpts...)
+	if err != nil {
 		_ = srv.Close()
 
-		return "", err
+		return "", fmt.Errorf("exchange code: %w", err)
+	}
 
-	case <-ctx.Done():
+	if tok.RefreshToken == "" {
 		_ = srv.Close()
 
-		return "", fmt.Errorf("authorization canceled: %w", ctx.Err())
+		return "", errNoRefreshToken
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	_ = srv.Shutdown(shutdownCtx)
+
+	return tok.RefreshToken, nil
+}
+
+// printHeadlessInstructions explains how to finish a login from another
+// machine by forwarding the callback port over SSH.
+func printHeadlessInstructions(port, authURL string, wait time.Duration) {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "<this-host>"
 	}
+
+	if user := os.Getenv("USER"); user != "" {
+		host = user + "@" + host
+	}
+
+	fmt.Fprintf(os.Stderr, "Headless login: the browser must reach port %s on this machine.\n\n", port)
+	fmt.Fprintln(os.Stderr, "1. On the computer with your browser, forward the port in another terminal:")
+	fmt.Fprintf(os.Stderr, "     ssh -N -L %s:127.0.0.1:%s %s\n", port, port, host)
+	fmt.Fprintln(os.Stderr, "2. Open this URL in that browser and approve access:")
+	fmt.Fprintf(os.Stderr, "     %s\n", authURL)
+	fmt.Fprintf(os.Stderr, "3. Front redirects to https://localhost:%s/callback. Accept the self-signed\n", port)
+	fmt.Fprintln(os.Stderr, "   certificate warning; login then completes here.")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintf(os.Stderr, "Waiting for the callback (expires in %s)...\n", wait)
+}
+
+// timeLeft returns the time until ctx's deadline, rounded to the second.
+func timeLeft(ctx context.Context) time.Duration {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return 0
+	}
+
+	return time.Until(deadline).Round(time.Second)
 }
 
 func randomState() (string, error) {
@@ -0,0 +1,89 @@
+package auth
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"github.com/dedene/frontapp-cli/internal/config"
+)
+
+func TestAuthorizeHeadlessWithPKCE(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+
+	port := ln.Addr().(*net.TCPAddr).Port
+	_ = ln.Close()
+
+	if err := config.WriteClientCredentials("default", config.OAuthCredentials{
+		ClientID:    "public-client",
+		RedirectURI: fmt.Sprintf("https://localhost:%d/callback", port),
+	}); err != nil {
+		t.Fatalf("write credentials: %v", err)
+	}
+
+	var verifier, secret string
+
+	tokenSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		verifier = r.PostForm.Get("code_verifier")
+		_, secret, _ = r.BasicAuth()
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"at","refresh_token":"rt","token_type":"bearer"}`))
+	}))
+	defer tokenSrv.Close()
+
+	oldEndpoint, oldState, oldBrowser := frontEndpoint, randomStateFn, openBrowserFn
+	frontEndpoint = oauth2.Endpoint{AuthURL: tokenSrv.URL + "/authorize", TokenURL: tokenSrv.URL + "/token"}
+	randomStateFn = func() (string, error) { return "state", nil }
+	openBrowserFn = func(string) error {
+		t.Error("headless login opened a browser")
+
+		return nil
+	}
+
+	t.Cleanup(func() { frontEndpoint, randomStateFn, openBrowserFn = oldEndpoint, oldState, oldBrowser })
+
+	go func() {
+		client := &http.Client{Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, //nolint:gosec // self-signed callback cert
+		}}
+		callback := fmt.Sprintf("https://127.0.0.1:%d/callback?code=abc&state=state", port)
+
+		for range 100 {
+			if resp, err := client.Get(callback); err == nil { //nolint:noctx // test
+				_ = resp.Body.Close()
+
+				return
+			}
+
+			time.Sleep(50 * time.Millisecond)
+		}
+	}()
+
+	refresh, err := Authorize(context.Background(), AuthorizeOptions{Headless: true, Timeout: 10 * time.Second})
+	if err != nil {
+		t.Fatalf("Authorize: %v", err)
+	}
+
+	if refresh != "rt" {
+		t.Fatalf("refresh token = %q", refresh)
+	}
+
+	if verifier == "" || secret != "" {
+		t.Fatalf("expected a PKCE verifier and no secret, got verifier %q secret %q", verifier, secret)
+	}
+}
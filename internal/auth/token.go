@@ -4,18 +4,60 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/gofrs/flock"
 	"golang.org/x/oauth2"
 
 	"github.com/dedene/frontapp-cli/internal/config"
 )
 
-var ErrNotAuthenticated = errors.New("not authenticated")
+var (
+	ErrNotAuthenticated = errors.New("not authenticated")
+	errLockTimeout      = errors.New("timed out waiting for another frontcli process to refresh the token")
+)
+
+const (
+	// refreshSkew is how long before expiry an access token is refreshed, so
+	// requests never go out with a token about to lapse.
+	refreshSkew = 2 * time.Minute
+	// lockTimeout bounds how long a refresh waits for another process.
+	lockTimeout = 30 * time.Second
+)
+
+// APITokenEnv names the environment variable holding a Front API token. When
+// set it is used instead of any stored account.
+const APITokenEnv = "FRONT_API_TOKEN" //nolint:gosec // env var name
+
+// Authentication modes reported by Token.Mode.
+const (
+	ModeOAuth    = "oauth"
+	ModeAPIToken = "api-token"
+)
+
+// EnvAPIToken returns the API token from FRONT_API_TOKEN, if any.
+func EnvAPIToken() string {
+	return strings.TrimSpace(os.Getenv(APITokenEnv))
+}
+
+// Mode reports whether the account uses a static API token or OAuth.
+func (t Token) Mode() string {
+	if t.APIToken != "" {
+		return ModeAPIToken
+	}
+
+	return ModeOAuth
+}
 
-// TokenSource provides OAuth2 tokens with lazy refresh on 401.
-// Access tokens are kept in memory only; refresh tokens are stored in keyring.
+// TokenSource provides OAuth2 tokens, refreshing them shortly before they
+// expire and after a 401. Refresh tokens are stored in the keyring; access
+// tokens are also cached there when the store is an AccessTokenStore, so
+// later invocations reuse them. Refreshes are serialized across processes
+// with a file lock. Accounts stored with a static API token use it as is.
 type TokenSource struct {
 	mu           sync.Mutex
 	client       string
@@ -23,6 +65,8 @@ type TokenSource struct {
 	store        Store
 	accessToken  string
 	accessExpiry time.Time
+	static       bool   // accessToken is an API token and never expires
+	rejected     string // access token the API last refused
 }
 
 func NewTokenSource(client, email string, store Store) *TokenSource {
@@ -80,7 +124,7 @@ func (ts *TokenSource) Token() (*oauth2.Token, error) {
 	defer ts.mu.Unlock()
 
 	// Return cached access token if still valid
-	if ts.accessToken != "" && time.Now().Before(ts.accessExpiry) {
+	if ts.accessToken != "" && (ts.static || fresh(ts.accessExpiry)) {
 		return &oauth2.Token{
 			AccessToken: ts.accessToken,
 			Expiry:      ts.accessExpiry,
@@ -102,17 +146,52 @@ func (ts *TokenSource) Token() (*oauth2.Token, error) {
 func (ts *TokenSource) Invalidate() {
 	ts.mu.Lock()
 	defer ts.mu.Unlock()
+	ts.rejected = ts.accessToken
 	ts.accessToken = ""
 	ts.accessExpiry = time.Time{}
+	ts.static = false
+}
+
+// fresh reports whether a token expiring at expiry is still good to use.
+func fresh(expiry time.Time) bool {
+	return time.Now().Add(refreshSkew).Before(expiry)
 }
 
 func (ts *TokenSource) refresh() error {
-	// Get refresh token from keyring
+	unlock, err := lockAccount(ts.client, ts.email)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	cache, canCache := ts.store.(AccessTokenStore)
+
+	// Another process may have refreshed while we waited for the lock.
+	if canCache {
+		cached, err := cache.GetAccessToken(ts.client, ts.email)
+		if err == nil && cached.AccessToken != ts.rejected && fresh(cached.Expiry) {
+			ts.accessToken = cached.AccessToken
+			ts.accessExpiry = cached.Expiry
+
+			return nil
+		}
+	}
+
+	// Get refresh token from keyring; read it under the lock as another
+	// process may have rotated it.
 	tok, err := ts.store.GetToken(ts.client, ts.email)
 	if err != nil {
 		return fmt.Errorf("%w: %w", ErrNotAuthenticated, err)
 	}
 
+	if tok.APIToken != "" {
+		ts.accessToken = tok.APIToken
+		ts.accessExpiry = time.Time{}
+		ts.static = true
+
+		return nil
+	}
+
 	if tok.RefreshToken == "" {
 		return ErrNotAuthenticated
 	}
@@ -152,9 +231,46 @@ func (ts *TokenSource) refresh() error {
 		}
 	}
 
+	if canCache {
+		// Best effort: without the cache the next process just refreshes.
+		_ = cache.SetAccessToken(ts.client, ts.email, newTok)
+	}
+
 	return nil
 }
 
+// lockAccount takes the file lock serializing token refreshes for an
+// account, so concurrent processes don't both redeem the same refresh token
+// and overwrite each other's rotated one. The returned func releases it.
+func lockAccount(client, email string) (func(), error) {
+	dir, err := config.EnsureLocksDir()
+	if err != nil {
+		return nil, err
+	}
+
+	normalizedClient, err := config.NormalizeClientNameOrDefault(client)
+	if err != nil {
+		return nil, fmt.Errorf("normalize client: %w", err)
+	}
+
+	name := strings.NewReplacer("/", "_", "\\", "_").Replace(normalize(email))
+	lock := flock.New(filepath.Join(dir, fmt.Sprintf("token-%s-%s.lock", normalizedClient, name)))
+
+	ctx, cancel := context.WithTimeout(context.Background(), lockTimeout)
+	defer cancel()
+
+	ok, err := lock.TryLockContext(ctx, 100*time.Millisecond)
+	if err != nil && !errors.Is(err, context.DeadlineExceeded) {
+		return nil, fmt.Errorf("lock token refresh: %w", err)
+	}
+
+	if !ok {
+		return nil, errLockTimeout
+	}
+
+	return func() { _ = lock.Unlock() }, nil
+}
+
 // GetAuthenticatedEmail returns the email for the authenticated account,
 // or error if not authenticated.
 func GetAuthenticatedEmail(client string) (string, error) {
@@ -0,0 +1,176 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"github.com/dedene/frontapp-cli/internal/config"
+)
+
+type memStore struct {
+	tokens map[string]Token
+}
+
+func (m *memStore) Keys() ([]string, error) {
+	keys := make([]string, 0, len(m.tokens))
+	for k := range m.tokens {
+		keys = append(keys, k)
+	}
+
+	return keys, nil
+}
+
+func (m *memStore) SetToken(client, email string, tok Token) error {
+	m.tokens[tokenKey(client, email)] = tok
+
+	return nil
+}
+
+func (m *memStore) GetToken(client, email string) (Token, error) {
+	tok, ok := m.tokens[tokenKey(client, email)]
+	if !ok {
+		return Token{}, errors.New("not found")
+	}
+
+	return tok, nil
+}
+
+func (m *memStore) DeleteToken(client, email string) error {
+	delete(m.tokens, tokenKey(client, email))
+
+	return nil
+}
+
+func (m *memStore) ListTokens() ([]Token, error) {
+	out := make([]Token, 0, len(m.tokens))
+	for _, tok := range m.tokens {
+		out = append(out, tok)
+	}
+
+	return out, nil
+}
+
+// cachingStore is a memStore that also caches access tokens.
+type cachingStore struct {
+	*memStore
+	access map[string]*oauth2.Token
+}
+
+func (c *cachingStore) GetAccessToken(client, email string) (*oauth2.Token, error) {
+	tok, ok := c.access[tokenKey(client, email)]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+
+	return tok, nil
+}
+
+func (c *cachingStore) SetAccessToken(client, email string, tok *oauth2.Token) error {
+	c.access[tokenKey(client, email)] = tok
+
+	return nil
+}
+
+func TestTokenSourceUsesStoredAPIToken(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	store := &memStore{tokens: map[string]Token{}}
+	_ = store.SetToken("default", "ci@example.com", Token{Email: "ci@example.com", APIToken: "api-123"})
+
+	ts := NewTokenSource("default", "ci@example.com", store)
+
+	tok, err := ts.Token()
+	if err != nil {
+		t.Fatalf("Token: %v", err)
+	}
+
+	if tok.AccessToken != "api-123" || !tok.Expiry.IsZero() {
+		t.Fatalf("unexpected token: %+v", tok)
+	}
+
+	if mode := (Token{APIToken: "x"}).Mode(); mode != ModeAPIToken {
+		t.Fatalf("Mode = %q", mode)
+	}
+
+	if mode := (Token{RefreshToken: "x"}).Mode(); mode != ModeOAuth {
+		t.Fatalf("Mode = %q", mode)
+	}
+
+	missing := NewTokenSource("default", "nobody@example.com", store)
+	if _, err := missing.Token(); !errors.Is(err, ErrNotAuthenticated) {
+		t.Fatalf("expected ErrNotAuthenticated, got %v", err)
+	}
+}
+
+func TestTokenSourceSharesAccessTokens(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	if err := config.WriteClientCredentials("default", config.OAuthCredentials{ClientID: "id", ClientSecret: "secret"}); err != nil {
+		t.Fatalf("write credentials: %v", err)
+	}
+
+	var refreshes int
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		refreshes++
+
+		if got := r.PostForm.Get("refresh_token"); got != "rt" {
+			t.Errorf("refresh_token = %q", got)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"at","refresh_token":"rt","token_type":"bearer","expires_in":3600}`))
+	}))
+	defer srv.Close()
+
+	oldEndpoint := frontEndpoint
+	frontEndpoint = oauth2.Endpoint{TokenURL: srv.URL, AuthStyle: oauth2.AuthStyleInParams}
+
+	t.Cleanup(func() { frontEndpoint = oldEndpoint })
+
+	store := &cachingStore{memStore: &memStore{tokens: map[string]Token{}}, access: map[string]*oauth2.Token{}}
+	_ = store.SetToken("default", "a@example.com", Token{Email: "a@example.com", RefreshToken: "rt"})
+
+	token := func(ts *TokenSource) string {
+		t.Helper()
+
+		tok, err := ts.Token()
+		if err != nil {
+			t.Fatalf("Token: %v", err)
+		}
+
+		return tok.AccessToken
+	}
+
+	first := NewTokenSource("default", "a@example.com", store)
+	if token(first) != "at" || refreshes != 1 {
+		t.Fatalf("expected one refresh, got %d", refreshes)
+	}
+
+	// A second process reuses the cached access token.
+	if token(NewTokenSource("default", "a@example.com", store)) != "at" || refreshes != 1 {
+		t.Fatalf("expected the cached token to be reused, got %d refreshes", refreshes)
+	}
+
+	// Tokens about to expire are refreshed ahead of time.
+	store.access[tokenKey("default", "a@example.com")].Expiry = time.Now().Add(30 * time.Second)
+
+	if token(NewTokenSource("default", "a@example.com", store)); refreshes != 2 {
+		t.Fatalf("expected a proactive refresh, got %d refreshes", refreshes)
+	}
+
+	// A token the API rejected is not taken from the cache again.
+	first.Invalidate()
+
+	if token(first); refreshes != 3 {
+		t.Fatalf("expected a refresh after Invalidate, got %d refreshes", refreshes)
+	}
+}
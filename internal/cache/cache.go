@@ -0,0 +1,223 @@
+// Package cache stores slow-changing Front collections (tags, inboxes,
+// teammates, channels) on disk between runs.
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultTTL is how long cached entries are served before being refetched.
+const DefaultTTL = time.Hour
+
+const fileExt = ".json"
+
+// Store is a TTL cache of JSON documents, one file per key, in a directory
+// dedicated to one account.
+type Store struct {
+	dir     string
+	ttl     time.Duration
+	refresh bool
+	now     func() time.Time
+}
+
+type envelope struct {
+	FetchedAt time.Time       `json:"fetched_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// Entry describes one cached document.
+type Entry struct {
+	Account   string
+	Key       string
+	FetchedAt time.Time
+	Size      int64
+}
+
+// AccountDir returns the cache directory for an account under base.
+func AccountDir(base, account string) string {
+	account = strings.ToLower(strings.TrimSpace(account))
+	if account == "" {
+		account = "default"
+	}
+
+	return filepath.Join(base, url.PathEscape(account))
+}
+
+// New returns a store rooted at dir. A ttl of zero uses DefaultTTL. With
+// refresh set, Load always misses so every entry is refetched and rewritten.
+func New(dir string, ttl time.Duration, refresh bool) *Store {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+
+	return &Store{dir: dir, ttl: ttl, refresh: refresh, now: time.Now}
+}
+
+// Load decodes the entry for key into out. It reports false when the entry
+// is missing, expired or unreadable.
+func (s *Store) Load(key string, out any) bool {
+	if s.refresh {
+		return false
+	}
+
+	data, err := os.ReadFile(s.path(key))
+	if err != nil {
+		return false
+	}
+
+	var env envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return false
+	}
+
+	if s.now().Sub(env.FetchedAt) > s.ttl {
+		return false
+	}
+
+	return json.Unmarshal(env.Data, out) == nil
+}
+
+// Store writes v as the entry for key.
+func (s *Store) Store(key string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("encode cache entry: %w", err)
+	}
+
+	b, err := json.Marshal(envelope{FetchedAt: s.now(), Data: data})
+	if err != nil {
+		return fmt.Errorf("encode cache entry: %w", err)
+	}
+
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return fmt.Errorf("ensure cache dir: %w", err)
+	}
+
+	// Each writer gets its own temp file so concurrent processes storing
+	// the same key never interleave; the rename makes the entry appear
+	// atomically.
+	path := s.path(key)
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("write cache entry: %w", err)
+	}
+
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(b); err != nil {
+		_ = tmp.Close()
+
+		return fmt.Errorf("write cache entry: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write cache entry: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("commit cache entry: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes the entry for key, if present.
+func (s *Store) Delete(key string) error {
+	if err := os.Remove(s.path(key)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("delete cache entry: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Store) path(key string) string {
+	return filepath.Join(s.dir, key+fileExt)
+}
+
+// Clear removes every cached entry under base, for all accounts.
+func Clear(base string) error {
+	if err := os.RemoveAll(base); err != nil {
+		return fmt.Errorf("clear cache: %w", err)
+	}
+
+	return nil
+}
+
+// List returns the entries cached under base for all accounts, sorted by
+// account and key.
+func List(base string) ([]Entry, error) {
+	accounts, err := os.ReadDir(base)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("read cache dir: %w", err)
+	}
+
+	var entries []Entry
+
+	for _, acct := range accounts {
+		if !acct.IsDir() {
+			continue
+		}
+
+		account, err := url.PathUnescape(acct.Name())
+		if err != nil {
+			account = acct.Name()
+		}
+
+		files, err := os.ReadDir(filepath.Join(base, acct.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("read cache dir: %w", err)
+		}
+
+		for _, f := range files {
+			if f.IsDir() || !strings.HasSuffix(f.Name(), fileExt) {
+				continue
+			}
+
+			entry, ok := readEntry(filepath.Join(base, acct.Name(), f.Name()))
+			if !ok {
+				continue
+			}
+
+			entry.Account = account
+			entry.Key = strings.TrimSuffix(f.Name(), fileExt)
+			entries = append(entries, entry)
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Account != entries[j].Account {
+			return entries[i].Account < entries[j].Account
+		}
+
+		return entries[i].Key < entries[j].Key
+	})
+
+	return entries, nil
+}
+
+func readEntry(path string) (Entry, bool) {
+	data, err := os.ReadFile(path) //nolint:gosec // path is inside the cache dir
+	if err != nil {
+		return Entry{}, false
+	}
+
+	var env envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return Entry{}, false
+	}
+
+	return Entry{FetchedAt: env.FetchedAt, Size: int64(len(data))}, true
+}
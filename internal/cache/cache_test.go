@@ -0,0 +1,50 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStoreRoundTripAndExpiry(t *testing.T) {
+	base := t.TempDir()
+	now := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	s := New(AccountDir(base, "Me@Example.com"), time.Minute, false)
+	s.now = func() time.Time { return now }
+
+	if err := s.Store("tags", []string{"a", "b"}); err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+
+	var got []string
+	if !s.Load("tags", &got) || len(got) != 2 || got[1] != "b" {
+		t.Fatalf("expected cache hit, got %v", got)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if s.Load("tags", &got) {
+		t.Fatal("expected expired entry to miss")
+	}
+
+	entries, err := List(base)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+
+	if len(entries) != 1 || entries[0].Account != "me@example.com" || entries[0].Key != "tags" {
+		t.Fatalf("unexpected entries: %+v", entries)
+	}
+}
+
+func TestStoreRefreshAlwaysMisses(t *testing.T) {
+	dir := t.TempDir()
+
+	if err := New(dir, 0, false).Store("inboxes", map[string]int{"n": 1}); err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+
+	var got map[string]int
+	if New(dir, 0, true).Load("inboxes", &got) {
+		t.Fatal("expected refresh store to miss")
+	}
+}
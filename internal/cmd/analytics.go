@@ -0,0 +1,606 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+	"github.com/dedene/frontapp-cli/internal/errfmt"
+	"github.com/dedene/frontapp-cli/internal/output"
+	"github.com/dedene/frontapp-cli/internal/report"
+)
+
+type AnalyticsCmd struct {
+	Report AnalyticsReportCmd `cmd:"" help:"Analytics reports (computed metrics)"`
+	Export AnalyticsExportCmd `cmd:"" help:"Analytics exports (message or event CSV files)"`
+}
+
+type AnalyticsReportCmd struct {
+	Create AnalyticsReportCreateCmd `cmd:"" help:"Create a report and wait for its metrics"`
+	Get    AnalyticsReportGetCmd    `cmd:"" help:"Get a report"`
+}
+
+type AnalyticsExportCmd struct {
+	Create   AnalyticsExportCreateCmd   `cmd:"" help:"Create an export, wait for it and download the file"`
+	Get      AnalyticsExportGetCmd      `cmd:"" help:"Get an export"`
+	Download AnalyticsExportDownloadCmd `cmd:"" help:"Download a finished export"`
+}
+
+// AnalyticsQueryFlags select the time range and resources of a report or
+// export.
+type AnalyticsQueryFlags struct {
+	Start    string            `help:"Start of the range: Unix seconds, RFC3339 or YYYY-MM-DD (default: 7 days ago)"`
+	End      string            `help:"End of the range (default: now)"`
+	Timezone string            `help:"Timezone for the range, e.g. Europe/Brussels (default: the company's)"`
+	Filters  map[string]string `help:"Restrict to resources: inbox=, tag=, teammate=, channel= or account= with comma-separated names or IDs (repeatable)"`
+}
+
+// AnalyticsWaitFlags control polling of asynchronous analytics jobs.
+type AnalyticsWaitFlags struct {
+	PollInterval time.Duration `help:"Time between status checks" name:"poll-interval" default:"2s"`
+	Timeout      time.Duration `help:"Give up waiting after this long" default:"10m"`
+}
+
+var errAnalyticsFailed = errors.New("analytics job failed")
+
+func (f AnalyticsQueryFlags) resolve(ctx context.Context, client *api.Client, flags *RootFlags) (float64, float64, *api.AnalyticsFilters, error) {
+	start, err := parseSearchTime(f.Start)
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("invalid --start: %w", err)
+	}
+
+	end, err := parseSearchTime(f.End)
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("invalid --end: %w", err)
+	}
+
+	now := time.Now()
+	if start == 0 {
+		start = float64(now.AddDate(0, 0, -7).Unix())
+	}
+
+	if end == 0 {
+		end = float64(now.Unix())
+	}
+
+	if end <= start {
+		return 0, 0, nil, fmt.Errorf("--end must be later than --start")
+	}
+
+	filters, err := f.filters(ctx, newResolver(client, flags))
+	if err != nil {
+		return 0, 0, nil, err
+	}
+
+	return start, end, filters, nil
+}
+
+func (f AnalyticsQueryFlags) filters(ctx context.Context, r *resolver) (*api.AnalyticsFilters, error) {
+	var filters api.AnalyticsFilters
+
+	keys := make([]string, 0, len(f.Filters))
+	for key := range f.Filters {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		for _, ref := range strings.Split(f.Filters[key], ",") {
+			ref = strings.TrimSpace(ref)
+			if ref == "" {
+				continue
+			}
+
+			var (
+				id  string
+				err error
+			)
+
+			switch strings.ToLower(key) {
+			case "inbox", "inboxes":
+				id, err = r.inboxID(ctx, ref)
+				filters.InboxIDs = appendID(filters.InboxIDs, id)
+			case "tag", "tags":
+				id, err = r.tagID(ctx, ref)
+				filters.TagIDs = appendID(filters.TagIDs, id)
+			case "teammate", "teammates":
+				id, err = r.teammateID(ctx, ref)
+				filters.TeammateIDs = appendID(filters.TeammateIDs, id)
+			case "channel", "channels":
+				id, err = r.channelID(ctx, ref)
+				filters.ChannelIDs = appendID(filters.ChannelIDs, id)
+			case "account", "accounts":
+				filters.AccountIDs = appendID(filters.AccountIDs, ref)
+			default:
+				return nil, fmt.Errorf("unknown filter %q (use inbox, tag, teammate, channel or account)", key)
+			}
+
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if filters.Empty() {
+		return nil, nil //nolint:nilnil // no filters means the whole company
+	}
+
+	return &filters, nil
+}
+
+func appendID(ids []string, id string) []string {
+	if id == "" {
+		return ids
+	}
+
+	return append(ids, id)
+}
+
+// waitAnalytics polls fetch until the job is done or failed, printing
+// progress changes to stderr.
+func waitAnalytics(ctx context.Context, f AnalyticsWaitFlags, label string, fetch func(context.Context) (string, int, error)) error {
+	ctx, cancel := context.WithTimeout(ctx, f.Timeout)
+	defer cancel()
+
+	interval := max(f.PollInterval, 100*time.Millisecond)
+	last := -1
+
+	for {
+		status, progress, err := fetch(ctx)
+		if err != nil {
+			return err
+		}
+
+		switch status {
+		case api.AnalyticsDone:
+			fmt.Fprintf(os.Stderr, "%s: done\n", label)
+
+			return nil
+		case api.AnalyticsFailed:
+			return fmt.Errorf("%s: %w", label, errAnalyticsFailed)
+		}
+
+		if progress != last {
+			fmt.Fprintf(os.Stderr, "%s: %s (%d%%)\n", label, status, progress)
+			last = progress
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%s: still %s after %s", label, status, f.Timeout)
+		case <-time.After(interval):
+		}
+	}
+}
+
+type AnalyticsReportCreateCmd struct {
+	Metrics []string `help:"Metrics to compute, e.g. avg_first_response_time,num_messages_received" required:"" sep:","`
+	NoWait  bool     `help:"Return immediately instead of waiting for the report" name:"no-wait"`
+
+	AnalyticsQueryFlags `embed:""`
+	AnalyticsWaitFlags  `embed:""`
+}
+
+func (c *AnalyticsReportCreateCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	start, end, filters, err := c.resolve(ctx, client, flags)
+	if err != nil {
+		return err
+	}
+
+	rep, err := client.CreateAnalyticsReport(ctx, api.AnalyticsReportRequest{
+		Start:    start,
+		End:      end,
+		Timezone: c.Timezone,
+		Filters:  filters,
+		Metrics:  c.Metrics,
+	})
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	if !c.NoWait && rep.Status != api.AnalyticsDone {
+		id := rep.ID()
+
+		err = waitAnalytics(ctx, c.AnalyticsWaitFlags, "Report "+id, func(ctx context.Context) (string, int, error) {
+			rep, err = client.GetAnalyticsReport(ctx, id)
+			if err != nil {
+				return "", 0, err
+			}
+
+			return rep.Status, rep.Progress, nil
+		})
+		if err != nil {
+			fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+			return err
+		}
+	}
+
+	return writeAnalyticsReport(mode, rep)
+}
+
+type AnalyticsReportGetCmd struct {
+	ID   string `arg:"" help:"Report UID"`
+	Wait bool   `help:"Wait for the report to finish"`
+
+	AnalyticsWaitFlags `embed:""`
+}
+
+func (c *AnalyticsReportGetCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	rep, err := client.GetAnalyticsReport(ctx, c.ID)
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	if c.Wait && rep.Status != api.AnalyticsDone {
+		err = waitAnalytics(ctx, c.AnalyticsWaitFlags, "Report "+c.ID, func(ctx context.Context) (string, int, error) {
+			rep, err = client.GetAnalyticsReport(ctx, c.ID)
+			if err != nil {
+				return "", 0, err
+			}
+
+			return rep.Status, rep.Progress, nil
+		})
+		if err != nil {
+			fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+			return err
+		}
+	}
+
+	return writeAnalyticsReport(mode, rep)
+}
+
+var analyticsMetricColumns = output.Columns[api.AnalyticsMetric]{
+	{Name: "id", Header: "METRIC", Value: func(m api.AnalyticsMetric) string { return m.ID }},
+	{Name: "type", Header: "TYPE", Value: func(m api.AnalyticsMetric) string { return m.Type }},
+	{Name: "value", Header: "VALUE", Value: formatMetricValue},
+}
+
+func formatMetricValue(m api.AnalyticsMetric) string {
+	switch v := m.Value.(type) {
+	case nil:
+		return ""
+	case float64:
+		switch m.Type {
+		case "duration":
+			return report.FormatDuration(v)
+		case "percentage":
+			return strconv.FormatFloat(v, 'f', 1, 64) + "%"
+		default:
+			return strconv.FormatFloat(v, 'f', -1, 64)
+		}
+	case string:
+		return v
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+
+		return string(data)
+	}
+}
+
+func writeAnalyticsReport(mode output.Mode, rep *api.AnalyticsReport) error {
+	if mode.JSON {
+		return output.Write(os.Stdout, mode, rep)
+	}
+
+	if rep.Status != api.AnalyticsDone {
+		fmt.Fprintf(os.Stdout, "Report %s is %s (%d%%)\n", rep.ID(), rep.Status, rep.Progress)
+
+		return nil
+	}
+
+	return output.WriteRows(os.Stdout, mode, analyticsMetricColumns, analyticsMetricColumns.Names(), rep.Metrics)
+}
+
+type AnalyticsExportCreateCmd struct {
+	Type    string   `help:"What to export: messages or events" enum:"messages,events" default:"messages"`
+	Columns []string `help:"Columns to include (default: all)" sep:","`
+	Out     string   `help:"File to download the export to (default: the export's filename in the current directory)" type:"path"`
+	NoWait  bool     `help:"Return immediately instead of waiting for the export and downloading it" name:"no-wait"`
+
+	AnalyticsQueryFlags `embed:""`
+	AnalyticsWaitFlags  `embed:""`
+}
+
+func (c *AnalyticsExportCreateCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	start, end, filters, err := c.resolve(ctx, client, flags)
+	if err != nil {
+		return err
+	}
+
+	exp, err := client.CreateAnalyticsExport(ctx, api.AnalyticsExportRequest{
+		Start:    start,
+		End:      end,
+		Timezone: c.Timezone,
+		Filters:  filters,
+		Type:     c.Type,
+		Columns:  c.Columns,
+	})
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	if c.NoWait {
+		return writeAnalyticsExport(mode, exp, "")
+	}
+
+	exp, err = waitAnalyticsExport(ctx, client, c.AnalyticsWaitFlags, exp)
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	path, err := downloadAnalyticsExport(ctx, client, exp, c.Out)
+	if err != nil {
+		return err
+	}
+
+	return writeAnalyticsExport(mode, exp, path)
+}
+
+type AnalyticsExportGetCmd struct {
+	ID   string `arg:"" help:"Export ID"`
+	Wait bool   `help:"Wait for the export to finish"`
+
+	AnalyticsWaitFlags `embed:""`
+}
+
+func (c *AnalyticsExportGetCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	exp, err := client.GetAnalyticsExport(ctx, c.ID)
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	if c.Wait {
+		if exp, err = waitAnalyticsExport(ctx, client, c.AnalyticsWaitFlags, exp); err != nil {
+			fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+			return err
+		}
+	}
+
+	return writeAnalyticsExport(mode, exp, "")
+}
+
+type AnalyticsExportDownloadCmd struct {
+	ID     string `arg:"" help:"Export ID"`
+	Out    string `help:"File to download the export to (default: the export's filename in the current directory)" type:"path"`
+	NoWait bool   `help:"Fail instead of waiting when the export is not finished yet" name:"no-wait"`
+
+	AnalyticsWaitFlags `embed:""`
+}
+
+func (c *AnalyticsExportDownloadCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	exp, err := client.GetAnalyticsExport(ctx, c.ID)
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	if exp.Status != api.AnalyticsDone {
+		if c.NoWait {
+			return fmt.Errorf("export %s is %s (%d%%)", c.ID, exp.Status, exp.Progress)
+		}
+
+		if exp, err = waitAnalyticsExport(ctx, client, c.AnalyticsWaitFlags, exp); err != nil {
+			fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+			return err
+		}
+	}
+
+	path, err := downloadAnalyticsExport(ctx, client, exp, c.Out)
+	if err != nil {
+		return err
+	}
+
+	return writeAnalyticsExport(mode, exp, path)
+}
+
+func waitAnalyticsExport(ctx context.Context, client *api.Client, f AnalyticsWaitFlags, exp *api.AnalyticsExport) (*api.AnalyticsExport, error) {
+	if exp.Status == api.AnalyticsDone {
+		return exp, nil
+	}
+
+	id := exp.ID()
+
+	err := waitAnalytics(ctx, f, "Export "+id, func(ctx context.Context) (string, int, error) {
+		latest, err := client.GetAnalyticsExport(ctx, id)
+		if err != nil {
+			return "", 0, err
+		}
+
+		exp = latest
+
+		return exp.Status, exp.Progress, nil
+	})
+
+	return exp, err
+}
+
+// downloadAnalyticsExport downloads a finished export to out, or to the
+// export's filename in the current directory.
+func downloadAnalyticsExport(ctx context.Context, client *api.Client, exp *api.AnalyticsExport, out string) (string, error) {
+	if exp.URL == "" {
+		return "", fmt.Errorf("export %s has no download URL", exp.ID())
+	}
+
+	if out == "" {
+		out = sanitizeFilename(exp.Filename, "export-"+exp.ID()+".csv")
+	}
+
+	progress := &byteProgress{label: "Downloading " + filepath.Base(out), total: exp.Size}
+
+	size, err := downloadFile(ctx, client, exp.URL, out, progress)
+	progress.finish()
+
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return "", err
+	}
+
+	fmt.Fprintf(os.Stderr, "Downloaded %s (%s)\n", out, formatBytes(size))
+
+	return out, nil
+}
+
+func writeAnalyticsExport(mode output.Mode, exp *api.AnalyticsExport, path string) error {
+	if mode.JSON {
+		result := map[string]any{"export": exp}
+		if path != "" {
+			result["path"] = path
+		}
+
+		return output.Write(os.Stdout, mode, result)
+	}
+
+	fmt.Fprintf(os.Stdout, "ID:       %s\n", exp.ID())
+	fmt.Fprintf(os.Stdout, "Status:   %s (%d%%)\n", exp.Status, exp.Progress)
+
+	if exp.Size > 0 {
+		fmt.Fprintf(os.Stdout, "Size:     %s\n", formatBytes(exp.Size))
+	}
+
+	if path != "" {
+		fmt.Fprintf(os.Stdout, "File:     %s\n", path)
+	} else if exp.URL != "" {
+		fmt.Fprintf(os.Stdout, "URL:      %s\n", exp.URL)
+	}
+
+	return nil
+}
+
+// byteProgress prints a download's progress to stderr every megabyte.
+type byteProgress struct {
+	label   string
+	total   int64
+	written int64
+	printed int64
+}
+
+const progressStep = 1 << 20
+
+func (p *byteProgress) Write(b []byte) (int, error) {
+	p.written += int64(len(b))
+
+	if p.written-p.printed >= progressStep {
+		p.printed = p.written
+
+		if p.total > 0 {
+			fmt.Fprintf(os.Stderr, "\r%s: %s of %s", p.label, formatBytes(p.written), formatBytes(p.total))
+		} else {
+			fmt.Fprintf(os.Stderr, "\r%s: %s", p.label, formatBytes(p.written))
+		}
+	}
+
+	return len(b), nil
+}
+
+func (p *byteProgress) finish() {
+	if p.printed > 0 {
+		fmt.Fprintln(os.Stderr)
+	}
+}
+
+var _ io.Writer = (*byteProgress)(nil)
+
+func formatBytes(n int64) string {
+	const unit = 1024
+
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+)
+
+func TestAnalyticsExportCreateWaitsAndDownloads(t *testing.T) {
+	var polls atomic.Int32
+
+	var srv *httptest.Server
+
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "POST /analytics/exports":
+			var req api.AnalyticsExportRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Errorf("decode request: %v", err)
+			}
+
+			if req.Type != "events" || req.Start != 1717200000 || req.Filters == nil || req.Filters.AccountIDs[0] != "acc_1" {
+				t.Errorf("unexpected request: %+v", req)
+			}
+
+			_, _ = io.WriteString(w, `{"_links":{"self":"`+srv.URL+`/analytics/exports/exp_1"},"status":"running","progress":0}`)
+		case "GET /analytics/exports/exp_1":
+			if polls.Add(1) < 2 {
+				_, _ = io.WriteString(w, `{"_links":{"self":"`+srv.URL+`/analytics/exports/exp_1"},"status":"running","progress":50}`)
+
+				return
+			}
+
+			_, _ = io.WriteString(w, `{"_links":{"self":"`+srv.URL+`/analytics/exports/exp_1"},"status":"done","progress":100,
+				"url":"`+srv.URL+`/files/export.csv","filename":"../export.csv","size":8}`)
+		case "GET /files/export.csv":
+			_, _ = io.WriteString(w, "id,type\n")
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	old := newClientFromAuth
+	newClientFromAuth = func(_, _ string) (*api.Client, error) {
+		return api.NewClientWithBaseURL(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}), srv.URL), nil
+	}
+	t.Cleanup(func() { newClientFromAuth = old })
+
+	t.Chdir(t.TempDir())
+
+	cmd := AnalyticsExportCreateCmd{
+		Type: "events",
+		AnalyticsQueryFlags: AnalyticsQueryFlags{
+			Start:   "1717200000",
+			End:     "1717300000",
+			Filters: map[string]string{"account": "acc_1"},
+		},
+		AnalyticsWaitFlags: AnalyticsWaitFlags{PollInterval: time.Millisecond, Timeout: 5 * time.Second},
+	}
+	if err := cmd.Run(&RootFlags{JSON: true, Account: "test@example.com", NoCache: true}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if polls.Load() != 2 {
+		t.Fatalf("expected 2 polls, got %d", polls.Load())
+	}
+
+	data, err := os.ReadFile(filepath.Join(".", "_export.csv"))
+	if err != nil {
+		t.Fatalf("read export: %v", err)
+	}
+
+	if string(data) != "id,type\n" {
+		t.Fatalf("unexpected export content %q", data)
+	}
+}
+
+func TestAnalyticsExportFailed(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, `{"_links":{"self":"/analytics/exports/exp_1"},"status":"failed","progress":10}`)
+	}))
+	defer srv.Close()
+
+	old := newClientFromAuth
+	newClientFromAuth = func(_, _ string) (*api.Client, error) {
+		return api.NewClientWithBaseURL(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}), srv.URL), nil
+	}
+	t.Cleanup(func() { newClientFromAuth = old })
+
+	cmd := AnalyticsExportDownloadCmd{ID: "exp_1", AnalyticsWaitFlags: AnalyticsWaitFlags{PollInterval: time.Millisecond, Timeout: time.Second}}
+	if err := cmd.Run(&RootFlags{JSON: true, Account: "test@example.com", NoCache: true}); err == nil {
+		t.Fatal("expected an error for a failed export")
+	}
+}
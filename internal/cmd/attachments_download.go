@@ -0,0 +1,353 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+	"github.com/dedene/frontapp-cli/internal/config"
+	"github.com/dedene/frontapp-cli/internal/errfmt"
+	"github.com/dedene/frontapp-cli/internal/output"
+)
+
+type ConvAttachmentsCmd struct {
+	Download ConvAttachmentsDownloadCmd `cmd:"" help:"Download every attachment of conversations"`
+}
+
+// AttachmentDownloadFlags control where and how bulk downloads are written.
+type AttachmentDownloadFlags struct {
+	OutputDir string `help:"Directory to download into (default: the attachments dir in the config dir)" name:"output-dir" type:"path"`
+	Parallel  int    `help:"Concurrent downloads" default:"4"`
+}
+
+type ConvAttachmentsDownloadCmd struct {
+	IDs []string `arg:"" help:"Conversation IDs"`
+
+	AttachmentDownloadFlags `embed:""`
+}
+
+func (c *ConvAttachmentsDownloadCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	return downloadConversationAttachments(ctx, client, mode, c.IDs, c.AttachmentDownloadFlags)
+}
+
+// Download outcomes reported in the manifest.
+const (
+	downloadDownloaded = "downloaded"
+	downloadSkipped    = "skipped"
+	downloadFailed     = "failed"
+)
+
+// downloadResult is one manifest entry.
+type downloadResult struct {
+	ConversationID string `json:"conversation_id"`
+	MessageID      string `json:"message_id"`
+	AttachmentID   string `json:"attachment_id"`
+	Filename       string `json:"filename"`
+	Path           string `json:"path"`
+	Size           int64  `json:"size"`
+	Status         string `json:"status"`
+	Error          string `json:"error,omitempty"`
+}
+
+var downloadColumns = output.Columns[downloadResult]{
+	{Name: "status", Header: "STATUS", Value: func(r downloadResult) string { return r.Status }},
+	{Name: "conversation", Header: "CONVERSATION", Value: func(r downloadResult) string { return r.ConversationID }},
+	{Name: "message", Header: "MESSAGE", Value: func(r downloadResult) string { return r.MessageID }},
+	{Name: "size", Header: "SIZE", Value: func(r downloadResult) string { return strconv.FormatInt(r.Size, 10) }},
+	{Name: "path", Header: "PATH", Value: func(r downloadResult) string { return r.Path }},
+	{Name: "error", Header: "ERROR", Value: func(r downloadResult) string { return r.Error }},
+}
+
+var downloadFields = []string{"status", "size", "path", "error"}
+
+// downloadConversationAttachments downloads the attachments of every message
+// in convIDs into <dir>/<conv>/<msg>/<filename> and prints a manifest.
+func downloadConversationAttachments(ctx context.Context, client *api.Client, mode output.Mode, convIDs []string, f AttachmentDownloadFlags) error {
+	dir := f.OutputDir
+	if dir == "" {
+		var err error
+
+		dir, err = config.EnsureAttachmentsDir()
+		if err != nil {
+			return err
+		}
+	}
+
+	var results []downloadResult
+
+	for _, convID := range convIDs {
+		planned, err := planConversationDownloads(ctx, client, dir, convID)
+		if err != nil {
+			fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+			return err
+		}
+
+		results = append(results, planned...)
+	}
+
+	runDownloads(ctx, client, results, max(f.Parallel, 1))
+
+	if err := writeDownloadManifest(mode, results); err != nil {
+		return err
+	}
+
+	counts := map[string]int{}
+	for _, r := range results {
+		counts[r.Status]++
+	}
+
+	fmt.Fprintf(os.Stderr, "%d downloaded, %d skipped, %d failed in %s\n",
+		counts[downloadDownloaded], counts[downloadSkipped], counts[downloadFailed], dir)
+
+	if counts[downloadFailed] > 0 {
+		return fmt.Errorf("%d attachment(s) failed to download", counts[downloadFailed])
+	}
+
+	return nil
+}
+
+// planConversationDownloads lists the conversation's messages and picks a
+// target path for each attachment. Files already on disk with the expected
+// size are marked skipped.
+func planConversationDownloads(ctx context.Context, client *api.Client, dir, convID string) ([]downloadResult, error) {
+	var results []downloadResult
+
+	path := fmt.Sprintf("/conversations/%s/messages?limit=100", convID)
+
+	_, err := api.Paginate(ctx, client, path, api.PageOptions{}, func(msgs []api.Message) error {
+		for _, msg := range msgs {
+			msgDir := filepath.Join(dir, sanitizeFilename(convID, "conversation"), sanitizeFilename(msg.ID, "message"))
+			taken := make(map[string]bool)
+
+			for _, att := range msg.Attachments {
+				r := downloadResult{
+					ConversationID: convID,
+					MessageID:      msg.ID,
+					AttachmentID:   attachmentID(att),
+					Filename:       att.Filename,
+					Size:           att.Size,
+				}
+
+				r.Path, r.Status = pickDownloadPath(msgDir, sanitizeFilename(att.Filename, r.AttachmentID), att.Size, taken)
+				results = append(results, r)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// pickDownloadPath returns the first free "name", "name (2)", ... in dir.
+// A file already present with the expected size counts as downloaded.
+func pickDownloadPath(dir, name string, size int64, taken map[string]bool) (string, string) {
+	ext := filepath.Ext(name)
+	stem := strings.TrimSuffix(name, ext)
+
+	for n := 1; ; n++ {
+		candidate := name
+		if n > 1 {
+			candidate = fmt.Sprintf("%s (%d)%s", stem, n, ext)
+		}
+
+		if taken[candidate] {
+			continue
+		}
+
+		path := filepath.Join(dir, candidate)
+
+		info, err := os.Stat(path)
+		if err == nil && (size == 0 || info.Size() == size) {
+			taken[candidate] = true
+
+			return path, downloadSkipped
+		}
+
+		if err != nil {
+			taken[candidate] = true
+
+			return path, ""
+		}
+	}
+}
+
+// attachmentID returns the attachment's ID, falling back to the last
+// segment of its download URL.
+func attachmentID(att api.Attachment) string {
+	if att.ID != "" {
+		return att.ID
+	}
+
+	u := strings.TrimRight(att.URL, "/")
+
+	return u[strings.LastIndex(u, "/")+1:]
+}
+
+// sanitizeFilename makes name safe to use as a single path element on any
+// platform, falling back to fallback when nothing usable remains.
+func sanitizeFilename(name, fallback string) string {
+	name = strings.Map(func(r rune) rune {
+		switch {
+		case r < 0x20 || r == 0x7f:
+			return -1
+		case strings.ContainsRune(`<>:"/\|?*`, r):
+			return '_'
+		default:
+			return r
+		}
+	}, name)
+
+	name = strings.Trim(name, " .")
+	if name == "" {
+		name = strings.Trim(fallback, " ./\\")
+	}
+
+	if name == "" {
+		name = "attachment"
+	}
+
+	const maxLen = 200
+	if len(name) > maxLen {
+		ext := filepath.Ext(name)
+		if len(ext) > 20 {
+			ext = ""
+		}
+
+		name = strings.ToValidUTF8(name[:maxLen-len(ext)], "") + ext
+	}
+
+	return name
+}
+
+// runDownloads fetches every pending result in parallel, updating its status.
+func runDownloads(ctx context.Context, client *api.Client, results []downloadResult, parallel int) {
+	var mu sync.Mutex
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(parallel)
+
+	for i := range results {
+		if results[i].Status == downloadSkipped {
+			continue
+		}
+
+		g.Go(func() error {
+			size, err := downloadTo(ctx, client, results[i].AttachmentID, results[i].Path)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				results[i].Status = downloadFailed
+				results[i].Error = err.Error()
+
+				return nil
+			}
+
+			results[i].Status = downloadDownloaded
+			results[i].Size = size
+
+			return nil
+		})
+	}
+
+	_ = g.Wait()
+}
+
+// downloadTo writes the attachment to a temporary file next to path and
+// renames it into place, so an interrupted run never leaves a partial file
+// that a later run would skip.
+func downloadTo(ctx context.Context, client *api.Client, attachmentID, path string) (int64, error) {
+	if attachmentID == "" {
+		return 0, errors.New("attachment has no ID or download URL")
+	}
+
+	return downloadFile(ctx, client, "/download/"+attachmentID, path, nil)
+}
+
+// downloadFile downloads src (an API path or URL) to path through a
+// temporary file, copying the body to progress as well when it is set.
+func downloadFile(ctx context.Context, client *api.Client, src, path string, progress io.Writer) (int64, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return 0, fmt.Errorf("create directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".download-*")
+	if err != nil {
+		return 0, fmt.Errorf("create file: %w", err)
+	}
+
+	defer os.Remove(tmp.Name())
+
+	var w io.Writer = tmp
+	if progress != nil {
+		w = io.MultiWriter(tmp, progress)
+	}
+
+	if err := client.Download(ctx, src, w); err != nil {
+		tmp.Close()
+
+		return 0, err
+	}
+
+	info, err := tmp.Stat()
+	if err != nil {
+		tmp.Close()
+
+		return 0, fmt.Errorf("stat file: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return 0, fmt.Errorf("write file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return 0, fmt.Errorf("rename file: %w", err)
+	}
+
+	return info.Size(), nil
+}
+
+func writeDownloadManifest(mode output.Mode, results []downloadResult) error {
+	if mode.JSON {
+		if mode.NDJSON {
+			return output.Write(os.Stdout, mode, results)
+		}
+
+		return output.Write(os.Stdout, mode, map[string]any{"downloads": results})
+	}
+
+	if len(results) == 0 {
+		fmt.Fprintln(os.Stdout, "No attachments found.")
+
+		return nil
+	}
+
+	return output.WriteRows(os.Stdout, mode, downloadColumns, downloadFields, results)
+}
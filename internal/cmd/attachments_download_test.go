@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"golang.org/x/oauth2"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+)
+
+func TestSanitizeFilename(t *testing.T) {
+	tests := map[string]string{
+		"report.pdf":          "report.pdf",
+		"../../etc/passwd":    "_.._etc_passwd",
+		"a:b*c?.txt":          "a_b_c_.txt",
+		"bad\x00name\n.txt":   "badname.txt",
+		"...":                 "fil_1",
+		"  spaced name.doc  ": "spaced name.doc",
+	}
+
+	for in, want := range tests {
+		if got := sanitizeFilename(in, "fil_1"); got != want {
+			t.Errorf("sanitizeFilename(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestConvAttachmentsDownload(t *testing.T) {
+	var downloads atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/conversations/cnv_1/messages":
+			_, _ = io.WriteString(w, `{"_results":[{"id":"msg_1","attachments":[
+				{"id":"fil_a","filename":"log.txt","size":5},
+				{"id":"fil_b","filename":"log.txt","size":6},
+				{"url":"https://api2.frontapp.com/download/fil_c","filename":"kept.txt","size":4}
+			]}]}`)
+		case "/download/fil_a":
+			downloads.Add(1)
+			_, _ = io.WriteString(w, "first")
+		case "/download/fil_b":
+			downloads.Add(1)
+			_, _ = io.WriteString(w, "second")
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	old := newClientFromAuth
+	newClientFromAuth = func(_, _ string) (*api.Client, error) {
+		return api.NewClientWithBaseURL(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}), srv.URL), nil
+	}
+	t.Cleanup(func() { newClientFromAuth = old })
+
+	dir := t.TempDir()
+	msgDir := filepath.Join(dir, "cnv_1", "msg_1")
+
+	if err := os.MkdirAll(msgDir, 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(msgDir, "kept.txt"), []byte("done"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := ConvAttachmentsDownloadCmd{IDs: []string{"cnv_1"}, AttachmentDownloadFlags: AttachmentDownloadFlags{OutputDir: dir, Parallel: 2}}
+	if err := cmd.Run(&RootFlags{JSON: true, Account: "test@example.com", NoCache: true}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	for name, want := range map[string]string{"log.txt": "first", "log (2).txt": "second", "kept.txt": "done"} {
+		data, err := os.ReadFile(filepath.Join(msgDir, name))
+		if err != nil || string(data) != want {
+			t.Errorf("%s = %q, %v; want %q", name, data, err, want)
+		}
+	}
+
+	if downloads.Load() != 2 {
+		t.Errorf("downloads = %d, want 2", downloads.Load())
+	}
+
+	entries, _ := os.ReadDir(msgDir)
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), ".download-") {
+			t.Errorf("temporary file left behind: %s", e.Name())
+		}
+	}
+}
@@ -3,7 +3,9 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
+	"strings"
 	"time"
 
 	"golang.org/x/term"
@@ -19,6 +21,7 @@ type AuthCmd struct {
 	Logout AuthLogoutCmd `cmd:"" help:"Remove stored tokens"`
 	Status AuthStatusCmd `cmd:"" help:"Show authentication status"`
 	List   AuthListCmd   `cmd:"" help:"List authenticated accounts"`
+	Token  AuthTokenCmd  `cmd:"" help:"Manage static API tokens"`
 }
 
 type AuthSetupCmd struct {
@@ -26,12 +29,13 @@ type AuthSetupCmd struct {
 	ClientSecret string `name:"client-secret" help:"OAuth client secret (for non-interactive use)"`
 	ClientName   string `help:"Client name (default: default)" default:"default" name:"client-name"`
 	RedirectURI  string `help:"OAuth redirect URI" default:"https://localhost:8484/callback"`
+	PKCE         bool   `name:"pkce" help:"Public client: store no secret and log in with PKCE"`
 }
 
 func (c *AuthSetupCmd) Run() error {
 	secret := c.ClientSecret
 
-	if secret == "" {
+	if secret == "" && !c.PKCE {
 		if term.IsTerminal(int(os.Stdin.Fd())) {
 			fmt.Print("Client Secret: ")
 
@@ -44,7 +48,7 @@ func (c *AuthSetupCmd) Run() error {
 
 			secret = string(bytes)
 		} else {
-			return fmt.Errorf("client secret required: use --client-secret flag or run interactively")
+			return fmt.Errorf("client secret required: use --client-secret flag, --pkce or run interactively")
 		}
 	}
 
@@ -66,10 +70,13 @@ func (c *AuthSetupCmd) Run() error {
 }
 
 type AuthLoginCmd struct {
-	Email        string `help:"Email/identifier to associate with this token" name:"email"`
-	ClientName   string `help:"Client name" default:"default" name:"client-name"`
-	ForceConsent bool   `help:"Force consent prompt even if already authorized"`
-	Manual       bool   `help:"Manual authorization (paste URL instead of callback server)"`
+	Email        string        `help:"Email/identifier to associate with this token" name:"email"`
+	ClientName   string        `help:"Client name" default:"default" name:"client-name"`
+	ForceConsent bool          `help:"Force consent prompt even if already authorized"`
+	Manual       bool          `help:"Manual authorization (paste URL instead of callback server)"`
+	Headless     bool          `help:"Don't open a browser; print the URL and wait for the callback over an SSH port forward (default in SSH sessions)"`
+	PKCE         bool          `name:"pkce" help:"Send a PKCE code challenge even when the client has a secret"`
+	Timeout      time.Duration `help:"How long to wait for authorization" default:"3m"`
 }
 
 func (c *AuthLoginCmd) Run(flags *RootFlags) error {
@@ -79,7 +86,9 @@ func (c *AuthLoginCmd) Run(flags *RootFlags) error {
 		Client:       c.ClientName,
 		ForceConsent: c.ForceConsent,
 		Manual:       c.Manual,
-		Timeout:      3 * time.Minute,
+		Headless:     c.Headless || os.Getenv("SSH_CONNECTION") != "",
+		PKCE:         c.PKCE,
+		Timeout:      c.Timeout,
 	})
 	if err != nil {
 		return fmt.Errorf("authorization failed: %w", err)
@@ -171,6 +180,93 @@ func (c *AuthLoginCmd) fetchEmail(ctx context.Context, refreshToken string) (str
 	return "", fmt.Errorf("could not determine account identity")
 }
 
+type AuthTokenCmd struct {
+	Set AuthTokenSetCmd `cmd:"" help:"Store a Front API token for an account (no OAuth app needed)"`
+}
+
+type AuthTokenSetCmd struct {
+	Email      string `help:"Email/identifier to associate with this token" name:"email"`
+	ClientName string `help:"Client name" default:"default" name:"client-name"`
+	Token      string `help:"API token (read from stdin or prompted for when omitted)" name:"token"`
+	NoVerify   bool   `help:"Store the token without checking it against the API" name:"no-verify"`
+}
+
+func (c *AuthTokenSetCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	email := c.Email
+	if email == "" && flags != nil && flags.Account != "" {
+		email = flags.Account
+	}
+
+	if email == "" {
+		return fmt.Errorf("email required: use --email to name the account")
+	}
+
+	token, err := c.readToken()
+	if err != nil {
+		return err
+	}
+
+	if !c.NoVerify {
+		if _, err := api.NewClientWithToken(token).Me(ctx); err != nil {
+			return fmt.Errorf("verify token: %w", err)
+		}
+	}
+
+	store, err := auth.OpenDefault()
+	if err != nil {
+		return fmt.Errorf("open keyring: %w", err)
+	}
+
+	tok := auth.Token{
+		Email:     email,
+		APIToken:  token,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	if err := store.SetToken(c.ClientName, email, tok); err != nil {
+		return fmt.Errorf("store token: %w", err)
+	}
+
+	fmt.Fprintf(os.Stdout, "API token stored for %s\n", email)
+
+	return nil
+}
+
+func (c *AuthTokenSetCmd) readToken() (string, error) {
+	token := c.Token
+
+	if token == "" {
+		if term.IsTerminal(int(os.Stdin.Fd())) {
+			fmt.Print("API Token: ")
+
+			bytes, err := term.ReadPassword(int(os.Stdin.Fd()))
+			fmt.Println() // newline after hidden input
+
+			if err != nil {
+				return "", fmt.Errorf("failed to read token: %w", err)
+			}
+
+			token = string(bytes)
+		} else {
+			bytes, err := io.ReadAll(os.Stdin)
+			if err != nil {
+				return "", fmt.Errorf("failed to read token: %w", err)
+			}
+
+			token = string(bytes)
+		}
+	}
+
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return "", fmt.Errorf("API token required: use --token, pipe it on stdin or run interactively")
+	}
+
+	return token, nil
+}
+
 type AuthLogoutCmd struct {
 	Email      string `help:"Email/account to log out" name:"email"`
 	ClientName string `help:"Client name" default:"default" name:"client-name"`
@@ -258,19 +354,18 @@ type AuthStatusCmd struct {
 }
 
 func (c *AuthStatusCmd) Run() error {
+	if auth.EnvAPIToken() != "" {
+		fmt.Fprintf(os.Stdout, "Using API token from %s\n", auth.APITokenEnv)
+
+		return nil
+	}
+
 	// Check if credentials exist
 	exists, err := config.ClientCredentialsExists(c.ClientName)
 	if err != nil {
 		return err
 	}
 
-	if !exists {
-		fmt.Fprintln(os.Stdout, "Not configured")
-		fmt.Fprintln(os.Stdout, "Run 'frontcli auth setup <client_id>' to configure.")
-
-		return nil
-	}
-
 	store, err := auth.OpenDefault()
 	if err != nil {
 		return fmt.Errorf("open keyring: %w", err)
@@ -295,6 +390,14 @@ func (c *AuthStatusCmd) Run() error {
 	}
 
 	if count == 0 {
+		if !exists {
+			fmt.Fprintln(os.Stdout, "Not configured")
+			fmt.Fprintln(os.Stdout, "Run 'frontcli auth setup <client_id>' to configure OAuth,")
+			fmt.Fprintf(os.Stdout, "or 'frontcli auth token set --email <email>' or %s to use an API token.\n", auth.APITokenEnv)
+
+			return nil
+		}
+
 		fmt.Fprintln(os.Stdout, "OAuth credentials configured but not authenticated.")
 		fmt.Fprintln(os.Stdout, "Run 'frontcli auth login' to authenticate.")
 
@@ -305,7 +408,7 @@ func (c *AuthStatusCmd) Run() error {
 
 	for _, tok := range tokens {
 		if tok.Client == normalizedClient {
-			fmt.Fprintf(os.Stdout, "  - %s (since %s)\n", tok.Email, tok.CreatedAt.Format("2006-01-02"))
+			fmt.Fprintf(os.Stdout, "  - %s (%s, since %s)\n", tok.Email, tok.Mode(), tok.CreatedAt.Format("2006-01-02"))
 		}
 	}
 
@@ -315,6 +418,10 @@ func (c *AuthStatusCmd) Run() error {
 type AuthListCmd struct{}
 
 func (c *AuthListCmd) Run() error {
+	if auth.EnvAPIToken() != "" {
+		fmt.Fprintf(os.Stdout, "%s is set and takes precedence over stored accounts.\n", auth.APITokenEnv)
+	}
+
 	store, err := auth.OpenDefault()
 	if err != nil {
 		return fmt.Errorf("open keyring: %w", err)
@@ -334,8 +441,8 @@ func (c *AuthListCmd) Run() error {
 	fmt.Fprintln(os.Stdout, "Authenticated accounts:")
 
 	for _, tok := range tokens {
-		fmt.Fprintf(os.Stdout, "  %s (client: %s, since %s)\n",
-			tok.Email, tok.Client, tok.CreatedAt.Format("2006-01-02"))
+		fmt.Fprintf(os.Stdout, "  %s (client: %s, %s, since %s)\n",
+			tok.Email, tok.Client, tok.Mode(), tok.CreatedAt.Format("2006-01-02"))
 	}
 
 	return nil
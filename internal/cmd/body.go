@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+	"github.com/dedene/frontapp-cli/internal/markdown"
+)
+
+// BodyFormatFlags are embedded by commands that send a message body.
+type BodyFormatFlags struct {
+	Format string `help:"Body format: markdown (rendered to HTML), html (sent as-is), text (escaped, line breaks kept) or auto (markdown for --edit bodies, html otherwise)" enum:"auto,markdown,html,text" default:"auto"`
+}
+
+// formatAuto picks the body format from where the body came from.
+const formatAuto = "auto"
+
+// render converts body to the HTML sent to Front. Bodies written in the
+// editor are plain text with "> " quotes, so without an explicit --format
+// they are rendered as markdown rather than sent as HTML.
+func (f BodyFormatFlags) render(body string, edited bool) (string, error) {
+	format := f.Format
+	if format == formatAuto || format == "" {
+		format = markdown.FormatHTML
+		if edited {
+			format = markdown.FormatMarkdown
+		}
+	}
+
+	return markdown.Render(body, format)
+}
+
+// AttachFlags are embedded by commands that can send local files.
+type AttachFlags struct {
+	Attach []string `help:"Attach a local file (repeatable, 25 MB in total)" type:"existingfile" sep:"none"`
+}
+
+// sendBody sends req as JSON, or as multipart/form-data when files are
+// attached.
+func sendBody(ctx context.Context, client *api.Client, method, path string, req map[string]any, files []api.FileUpload, out any) error {
+	if len(files) == 0 {
+		if method == http.MethodPatch {
+			return client.Patch(ctx, path, req, out)
+		}
+
+		return client.Post(ctx, path, req, out)
+	}
+
+	if method == http.MethodPatch {
+		return client.PatchMultipart(ctx, path, req, files, out)
+	}
+
+	return client.PostMultipart(ctx, path, req, files, out)
+}
@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+	"github.com/dedene/frontapp-cli/internal/errfmt"
+	"github.com/dedene/frontapp-cli/internal/output"
+)
+
+// BulkFlags control how an action is applied to many conversations.
+type BulkFlags struct {
+	IDsFrom         string `help:"Read conversation IDs from stdin (use '-' for stdin)" name:"ids-from"`
+	Parallel        int    `help:"Conversations processed concurrently" default:"4"`
+	ContinueOnError bool   `help:"Attempt every conversation even after a failure (default)" name:"continue-on-error" xor:"bulk-errors"`
+	FailFast        bool   `help:"Stop at the first failure and skip the remaining conversations" name:"fail-fast" xor:"bulk-errors"`
+}
+
+// ids returns the positional IDs followed by those read from --ids-from.
+func (f BulkFlags) ids(args ...string) ([]string, error) {
+	ids, err := collectIDs(args, f.IDsFrom)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("no conversation IDs provided")
+	}
+
+	return ids, nil
+}
+
+// Bulk outcomes reported per conversation.
+const (
+	bulkSucceeded = "succeeded"
+	bulkFailed    = "failed"
+	bulkSkipped   = "skipped"
+)
+
+// bulkResult is the outcome of an action on one conversation.
+type bulkResult struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+
+	err error
+}
+
+var bulkColumns = output.Columns[bulkResult]{
+	{Name: "id", Header: "ID", Value: func(r bulkResult) string { return r.ID }},
+	{Name: "status", Header: "STATUS", Value: func(r bulkResult) string { return r.Status }},
+	{Name: "error", Header: "ERROR", Value: func(r bulkResult) string { return r.Error }},
+}
+
+var bulkFields = []string{"id", "status", "error"}
+
+// bulkAction describes an action in the report: verb as in "Failed to
+// archive cnv_1" and done as a format taking the ID, e.g. "Archived %s".
+type bulkAction struct {
+	verb string
+	done string
+}
+
+// runBulk applies fn to every ID with up to f.Parallel calls in flight.
+// Requests still go through the client's rate limiter, which spaces them
+// out across workers. With f.FailFast no new call starts after a failure
+// and the IDs not attempted are reported as skipped.
+func runBulk(ctx context.Context, ids []string, f BulkFlags, fn func(ctx context.Context, id string) error) []bulkResult {
+	results := make([]bulkResult, len(ids))
+	for i, id := range ids {
+		results[i] = bulkResult{ID: id, Status: bulkSkipped}
+	}
+
+	var (
+		mu      sync.Mutex
+		stopped atomic.Bool
+	)
+
+	var g errgroup.Group
+
+	g.SetLimit(max(f.Parallel, 1))
+
+	for i := range ids {
+		if stopped.Load() {
+			break
+		}
+
+		g.Go(func() error {
+			if stopped.Load() {
+				return nil
+			}
+
+			err := fn(ctx, ids[i])
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				results[i].Status = bulkFailed
+				results[i].Error = err.Error()
+				results[i].err = err
+
+				if f.FailFast {
+					stopped.Store(true)
+				}
+
+				return nil
+			}
+
+			results[i].Status = bulkSucceeded
+
+			return nil
+		})
+	}
+
+	_ = g.Wait()
+
+	return results
+}
+
+// reportBulk prints the per-conversation results and, for more than one
+// conversation, a summary on stderr. It returns an ExitError when any
+// conversation failed.
+func reportBulk(mode output.Mode, action bulkAction, results []bulkResult) error {
+	if err := writeBulkResults(mode, action, results); err != nil {
+		return err
+	}
+
+	counts := map[string]int{}
+	for _, r := range results {
+		counts[r.Status]++
+	}
+
+	if len(results) > 1 {
+		fmt.Fprintf(os.Stderr, "%d succeeded, %d failed, %d skipped\n",
+			counts[bulkSucceeded], counts[bulkFailed], counts[bulkSkipped])
+	}
+
+	if counts[bulkFailed] == 0 {
+		return nil
+	}
+
+	if len(results) == 1 {
+		return &ExitError{Code: api.ExitError, Err: results[0].err}
+	}
+
+	return &ExitError{Code: api.ExitError, Err: fmt.Errorf("failed to %s %d of %d conversation(s)", action.verb, counts[bulkFailed], len(results))}
+}
+
+func writeBulkResults(mode output.Mode, action bulkAction, results []bulkResult) error {
+	if mode.JSON {
+		if mode.NDJSON {
+			return output.Write(os.Stdout, mode, results)
+		}
+
+		return output.Write(os.Stdout, mode, map[string]any{"results": results})
+	}
+
+	if mode.Plain || mode.Columnar() {
+		return output.WriteRows(os.Stdout, mode, bulkColumns, bulkFields, results)
+	}
+
+	for _, r := range results {
+		switch r.Status {
+		case bulkSucceeded:
+			fmt.Fprintf(os.Stdout, action.done+"\n", r.ID)
+		case bulkFailed:
+			if len(results) == 1 {
+				fmt.Fprint(os.Stderr, errfmt.Format(r.err))
+			} else {
+				fmt.Fprintf(os.Stderr, "Failed to %s %s: %s\n", action.verb, r.ID, r.Error)
+			}
+		default:
+			fmt.Fprintf(os.Stderr, "Skipped %s\n", r.ID)
+		}
+	}
+
+	return nil
+}
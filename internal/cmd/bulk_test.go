@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"golang.org/x/oauth2"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+	"github.com/dedene/frontapp-cli/internal/output"
+)
+
+func TestRunBulk(t *testing.T) {
+	ids := []string{"cnv_1", "cnv_2", "cnv_3"}
+	failOn2 := func(_ context.Context, id string) error {
+		if id == "cnv_2" {
+			return errors.New("boom")
+		}
+
+		return nil
+	}
+
+	statuses := func(results []bulkResult) string {
+		out := make([]string, len(results))
+		for i, r := range results {
+			out[i] = r.Status
+		}
+
+		return strings.Join(out, ",")
+	}
+
+	results := runBulk(context.Background(), ids, BulkFlags{Parallel: 3}, failOn2)
+	if got := statuses(results); got != "succeeded,failed,succeeded" {
+		t.Fatalf("continue on error: %s", got)
+	}
+
+	var exitErr *ExitError
+	if err := reportBulk(output.Mode{JSON: true, NDJSON: true}, bulkAction{verb: "archive", done: "Archived %s"}, results); !errors.As(err, &exitErr) || exitErr.Code != api.ExitError {
+		t.Fatalf("expected an ExitError, got %v", err)
+	}
+
+	results = runBulk(context.Background(), ids, BulkFlags{Parallel: 1, FailFast: true}, failOn2)
+	if got := statuses(results); got != "succeeded,failed,skipped" {
+		t.Fatalf("fail fast: %s", got)
+	}
+}
+
+func TestConvTagReadsIDsFrom(t *testing.T) {
+	var (
+		mu   sync.Mutex
+		seen []string
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		seen = append(seen, r.URL.Path)
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	old := newClientFromAuth
+	newClientFromAuth = func(_, _ string) (*api.Client, error) {
+		return api.NewClientWithBaseURL(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}), srv.URL), nil
+	}
+	t.Cleanup(func() { newClientFromAuth = old })
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("pipe: %v", err)
+	}
+	defer r.Close()
+
+	_, _ = w.WriteString("cnv_1\ncnv_2\n")
+	_ = w.Close()
+
+	oldStdin := os.Stdin
+	os.Stdin = r
+	t.Cleanup(func() { os.Stdin = oldStdin })
+
+	cmd := ConvTagCmd{Tag: "tag_abc", BulkFlags: BulkFlags{IDsFrom: "-", Parallel: 2}}
+	if err := cmd.Run(&RootFlags{Account: "test@example.com", NoCache: true}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if len(seen) != 2 || !strings.HasSuffix(seen[0], "/tags") {
+		t.Fatalf("unexpected requests: %v", seen)
+	}
+}
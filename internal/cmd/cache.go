@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/dedene/frontapp-cli/internal/cache"
+	"github.com/dedene/frontapp-cli/internal/config"
+	"github.com/dedene/frontapp-cli/internal/output"
+)
+
+type CacheCmd struct {
+	Status CacheStatusCmd `cmd:"" help:"Show cached collections and their age"`
+	Clear  CacheClearCmd  `cmd:"" help:"Delete all cached data"`
+}
+
+type CacheStatusCmd struct{}
+
+func (c *CacheStatusCmd) Run(flags *RootFlags) error {
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	base, err := config.CacheDir()
+	if err != nil {
+		return err
+	}
+
+	entries, err := cache.List(base)
+	if err != nil {
+		return err
+	}
+
+	if mode.JSON {
+		type entryJSON struct {
+			Account   string    `json:"account"`
+			Key       string    `json:"key"`
+			FetchedAt time.Time `json:"fetched_at"`
+			Size      int64     `json:"size"`
+		}
+
+		out := make([]entryJSON, 0, len(entries))
+		for _, e := range entries {
+			out = append(out, entryJSON{Account: e.Account, Key: e.Key, FetchedAt: e.FetchedAt, Size: e.Size})
+		}
+
+		return output.Write(os.Stdout, mode, map[string]any{"dir": base, "entries": out})
+	}
+
+	if len(entries) == 0 {
+		fmt.Fprintln(os.Stdout, "Cache is empty.")
+
+		return nil
+	}
+
+	tbl := output.NewModeTableWriter(os.Stdout, mode)
+	tbl.AddRow("ACCOUNT", "KEY", "FETCHED", "AGE", "SIZE")
+
+	for _, e := range entries {
+		age := time.Since(e.FetchedAt).Truncate(time.Second)
+		tbl.AddRow(e.Account, e.Key, e.FetchedAt.Format(time.RFC3339), age.String(), fmt.Sprintf("%d", e.Size))
+	}
+
+	return tbl.Flush()
+}
+
+type CacheClearCmd struct{}
+
+func (c *CacheClearCmd) Run() error {
+	base, err := config.CacheDir()
+	if err != nil {
+		return err
+	}
+
+	if err := cache.Clear(base); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(os.Stdout, "Cache cleared")
+
+	return nil
+}
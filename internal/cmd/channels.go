@@ -37,7 +37,7 @@ func (c *ChannelListCmd) Run(flags *RootFlags) error {
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, resp)
+		return output.Write(os.Stdout, mode, resp)
 	}
 
 	if len(resp.Results) == 0 {
@@ -46,14 +46,7 @@ func (c *ChannelListCmd) Run(flags *RootFlags) error {
 		return nil
 	}
 
-	tbl := output.NewTableWriter(os.Stdout, mode.Plain)
-	tbl.AddRow("ID", "TYPE", "NAME", "ADDRESS")
-
-	for _, ch := range resp.Results {
-		tbl.AddRow(output.FormatChannel(ch)...)
-	}
-
-	return tbl.Flush()
+	return output.WriteRows(os.Stdout, mode, output.ChannelColumns, output.ChannelFields, resp.Results)
 }
 
 type ChannelGetCmd struct {
@@ -81,7 +74,11 @@ func (c *ChannelGetCmd) Run(flags *RootFlags) error {
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, ch)
+		return output.Write(os.Stdout, mode, ch)
+	}
+
+	if mode.Columnar() {
+		return output.WriteItem(os.Stdout, mode, output.ChannelColumns, *ch)
 	}
 
 	fmt.Fprintf(os.Stdout, "ID:      %s\n", ch.ID)
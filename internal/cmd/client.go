@@ -1,37 +1,151 @@
 package cmd
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"time"
+
 	"github.com/dedene/frontapp-cli/internal/api"
 	"github.com/dedene/frontapp-cli/internal/auth"
+	"github.com/dedene/frontapp-cli/internal/cache"
 	"github.com/dedene/frontapp-cli/internal/config"
 )
 
 var newClientFromAuth = api.NewClientFromAuth
 
-// getClient creates an API client using stored auth credentials.
+// envAccount names the account used for caches when FRONT_API_TOKEN is set
+// and no account is selected.
+const envAccount = "api-token"
+
+// getClient creates an API client using FRONT_API_TOKEN or stored auth
+// credentials.
 func getClient(flags *RootFlags) (*api.Client, error) {
-	email, err := config.ResolveAccount(flags.Account)
+	clientName, email, err := resolveAccount(flags)
 	if err != nil {
 		return nil, err
 	}
 
+	var client *api.Client
+
+	if token := auth.EnvAPIToken(); token != "" {
+		client = api.NewClientWithToken(token)
+	} else {
+		client, err = newClientFromAuth(clientName, email)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	key := cacheKey(email)
+
+	if err := shareRateLimit(client, key); err != nil {
+		return nil, err
+	}
+
+	if !flags.NoCache {
+		mc, err := openMetadataCache(key, flags.RefreshCache)
+		if err != nil {
+			return nil, err
+		}
+
+		client.SetMetadataCache(mc)
+	}
+
+	return client, nil
+}
+
+// cacheKey names the metadata cache and shared rate-limit state for email.
+// FRONT_API_TOKEN may belong to any workspace, whatever account is selected,
+// so it is keyed by a hash of the token instead.
+func cacheKey(email string) string {
+	token := auth.EnvAPIToken()
+	if token == "" {
+		return email
+	}
+
+	sum := sha256.Sum256([]byte(token))
+
+	return "token-" + hex.EncodeToString(sum[:8])
+}
+
+// resolveAccount returns the OAuth client name and account email to use.
+func resolveAccount(flags *RootFlags) (string, string, error) {
+	email, err := config.ResolveAccount(flags.Account)
+	if err != nil {
+		return "", "", err
+	}
+
 	clientName := flags.Client
 	if clientName == "" {
 		clientName = "default"
 	}
 
+	if email == "" && auth.EnvAPIToken() != "" {
+		// The env token needs no stored account.
+		return config.DefaultClientName, envAccount, nil
+	}
+
 	if email == "" {
 		// Try to get email from stored tokens
 		email, err = auth.GetAuthenticatedEmail(clientName)
 		if err != nil {
-			return nil, &api.AuthError{Err: err}
+			return "", "", &api.AuthError{Err: err}
 		}
 	}
 
 	clientName, err = config.ResolveClientForAccount(email, flags.Client)
+	if err != nil {
+		return "", "", err
+	}
+
+	return clientName, email, nil
+}
+
+// openMetadataCache opens the per-account tag/inbox/teammate/channel cache.
+func openMetadataCache(key string, refresh bool) (*cache.Store, error) {
+	cfg, err := config.ReadConfig()
 	if err != nil {
 		return nil, err
 	}
 
-	return newClientFromAuth(clientName, email)
+	var ttl time.Duration
+
+	if cfg.CacheTTL != "" {
+		ttl, err = time.ParseDuration(cfg.CacheTTL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cache_ttl %q: %w", cfg.CacheTTL, err)
+		}
+	}
+
+	base, err := config.CacheDir()
+	if err != nil {
+		return nil, err
+	}
+
+	return cache.New(cache.AccountDir(base, key), ttl, refresh), nil
+}
+
+// shareRateLimit coordinates the client's rate limiter with other processes
+// for the same account when shared_rate_limit is enabled.
+func shareRateLimit(client *api.Client, key string) error {
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return err
+	}
+
+	if !cfg.SharedRateLimit {
+		return nil
+	}
+
+	dir, err := config.EnsureLocksDir()
+	if err != nil {
+		return err
+	}
+
+	client.RateLimiter().Share(filepath.Join(dir, "ratelimit-"+url.PathEscape(key)+".json"))
+
+	return nil
 }
@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/dedene/frontapp-cli/internal/auth"
+)
+
+func TestCacheKeySeparatesEnvTokens(t *testing.T) {
+	t.Setenv(auth.APITokenEnv, "")
+
+	if got := cacheKey("me@example.com"); got != "me@example.com" {
+		t.Fatalf("expected the account email without an env token, got %q", got)
+	}
+
+	t.Setenv(auth.APITokenEnv, "token-for-workspace-a")
+	a := cacheKey("me@example.com")
+
+	t.Setenv(auth.APITokenEnv, "token-for-workspace-b")
+	b := cacheKey("me@example.com")
+
+	if a == b || a == "me@example.com" || b == envAccount {
+		t.Fatalf("expected distinct token-derived keys, got %q and %q", a, b)
+	}
+
+	if cacheKey(envAccount) != b {
+		t.Fatalf("expected the key to depend only on the token, got %q and %q", cacheKey(envAccount), b)
+	}
+}
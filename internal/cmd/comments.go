@@ -18,6 +18,9 @@ type CommentCmd struct {
 
 type CommentListCmd struct {
 	ConvID string `arg:"" help:"Conversation ID"`
+	Limit  int    `help:"Maximum number of comments" default:"25"`
+
+	PaginationFlags `embed:""`
 }
 
 func (c *CommentListCmd) Run(flags *RootFlags) error {
@@ -33,15 +36,17 @@ func (c *CommentListCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
-	var resp api.ListResponse[api.Comment]
-	if err := client.Get(ctx, fmt.Sprintf("/conversations/%s/comments", c.ConvID), &resp); err != nil {
+	path := fmt.Sprintf("/conversations/%s/comments?limit=%d", c.ConvID, c.Limit)
+
+	resp, err := fetchList[api.Comment](ctx, client, path, c.PaginationFlags, mode)
+	if err != nil {
 		fmt.Fprint(os.Stderr, errfmt.Format(err))
 
 		return err
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, resp)
+		return output.Write(os.Stdout, mode, resp)
 	}
 
 	if len(resp.Results) == 0 {
@@ -50,37 +55,19 @@ func (c *CommentListCmd) Run(flags *RootFlags) error {
 		return nil
 	}
 
-	tbl := output.NewTableWriter(os.Stdout, mode.Plain)
-	tbl.AddRow("ID", "AUTHOR", "BODY", "DATE")
-
-	for _, comment := range resp.Results {
-		author := "-"
-		if comment.Author != nil {
-			author = comment.Author.Email
-			if author == "" {
-				author = comment.Author.Username
-			}
-		}
-
-		body := comment.Body
-		if len(body) > 50 {
-			body = body[:47] + "..."
-		}
-
-		tbl.AddRow(
-			comment.ID,
-			author,
-			body,
-			output.FormatTimestamp(comment.PostedAt),
-		)
+	if err := output.WriteRows(os.Stdout, mode, output.CommentColumns, output.CommentFields, resp.Results); err != nil {
+		return err
 	}
 
-	return tbl.Flush()
+	printMoreResultsHint(resp.Pagination.Next)
+
+	return nil
 }
 
 type CommentCreateCmd struct {
 	ConvID string `arg:"" help:"Conversation ID"`
-	Body   string `required:"" help:"Comment body (@mentions supported)"`
+	Body   string `help:"Comment body (@mentions supported)"`
+	Edit   bool   `help:"Write the comment in $EDITOR"`
 }
 
 func (c *CommentCreateCmd) Run(flags *RootFlags) error {
@@ -96,8 +83,23 @@ func (c *CommentCreateCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
+	body := c.Body
+
+	if c.Edit {
+		msg, err := editComposition(composition{Body: body}, nil, "", "")
+		if err != nil {
+			return err
+		}
+
+		body = msg.Body
+	}
+
+	if body == "" {
+		return fmt.Errorf("body is required (use --body or --edit)")
+	}
+
 	req := map[string]string{
-		"body": c.Body,
+		"body": body,
 	}
 
 	var result api.Comment
@@ -108,7 +110,7 @@ func (c *CommentCreateCmd) Run(flags *RootFlags) error {
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, result)
+		return output.Write(os.Stdout, mode, result)
 	}
 
 	fmt.Fprintf(os.Stdout, "Comment created: %s\n", result.ID)
@@ -141,7 +143,11 @@ func (c *CommentGetCmd) Run(flags *RootFlags) error {
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, comment)
+		return output.Write(os.Stdout, mode, comment)
+	}
+
+	if mode.Columnar() {
+		return output.WriteItem(os.Stdout, mode, output.CommentColumns, comment)
 	}
 
 	author := "-"
@@ -0,0 +1,418 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+	"github.com/dedene/frontapp-cli/internal/markdown"
+	"github.com/dedene/frontapp-cli/internal/output"
+)
+
+// Header names shown in the editor, in this order.
+const (
+	headerTo      = "To"
+	headerCc      = "Cc"
+	headerBcc     = "Bcc"
+	headerSubject = "Subject"
+	headerChannel = "Channel"
+)
+
+var (
+	messageHeaders = []string{headerTo, headerCc, headerBcc, headerSubject, headerChannel}
+	replyHeaders   = []string{headerTo, headerCc, headerBcc, headerSubject}
+)
+
+// replyHint explains the reply headers at the top of the editor file.
+const replyHint = "Leave To, Cc and Bcc empty to reply to the conversation's recipients."
+
+// errComposeAborted is returned when the editor is closed without a body or
+// without changes.
+var errComposeAborted = errors.New("aborted: message not sent")
+
+// composition is what the user fills in through --edit.
+type composition struct {
+	To      []string
+	Cc      []string
+	Bcc     []string
+	Subject string
+	Channel string
+	Body    string
+}
+
+// runEditor opens path in the user's editor. Tests replace it.
+var runEditor = func(path string) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+
+	if editor == "" {
+		editor = "vi"
+		if runtime.GOOS == "windows" {
+			editor = "notepad"
+		}
+	}
+
+	args, err := editorArgs(editor)
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.Command(args[0], append(args[1:], path)...) //nolint:gosec // the editor is chosen by the user
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("run editor %q: %w", editor, err)
+	}
+
+	return nil
+}
+
+// editorArgs turns a $VISUAL/$EDITOR value into argv without a shell, so
+// values like "code --wait" work on every platform, including Windows
+// without sh. A value naming an existing file is the program itself, spaces
+// and all; anything else is split on whitespace, honouring single and double
+// quotes and backslash-escaped spaces, quotes and backslashes.
+func editorArgs(editor string) ([]string, error) {
+	editor = strings.TrimSpace(editor)
+	if editor == "" {
+		return nil, fmt.Errorf("run editor: $VISUAL/$EDITOR is blank")
+	}
+
+	if info, err := os.Stat(editor); err == nil && !info.IsDir() {
+		return []string{editor}, nil
+	}
+
+	var (
+		args    []string
+		arg     strings.Builder
+		inArg   bool
+		quote   rune
+		escaped bool
+	)
+
+	for _, r := range editor {
+		switch {
+		case escaped:
+			if !strings.ContainsRune(" \t\"'\\", r) {
+				arg.WriteRune('\\')
+			}
+
+			arg.WriteRune(r)
+
+			escaped = false
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				arg.WriteRune(r)
+			}
+		case quote == '"':
+			switch r {
+			case '"':
+				quote = 0
+			case '\\':
+				escaped = true
+			default:
+				arg.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == '\\':
+			escaped = true
+			inArg = true
+		case r == ' ' || r == '\t':
+			if inArg {
+				args = append(args, arg.String())
+				arg.Reset()
+
+				inArg = false
+			}
+		default:
+			arg.WriteRune(r)
+
+			inArg = true
+		}
+	}
+
+	if quote != 0 || escaped {
+		return nil, fmt.Errorf("run editor: unterminated quote or escape in %q", editor)
+	}
+
+	if inArg {
+		args = append(args, arg.String())
+	}
+
+	return args, nil
+}
+
+// editComposition opens the editor on c with the given headers and an
+// optional quoted message, and returns what the user saved. It returns an
+// ExitError with api.ExitCancelled when the user leaves the body empty or
+// the file unchanged.
+func editComposition(c composition, headers []string, hint, quote string) (composition, error) {
+	initial := formatComposition(c, headers, hint, quote)
+
+	f, err := os.CreateTemp("", "frontcli-*.md")
+	if err != nil {
+		return composition{}, fmt.Errorf("create temp file: %w", err)
+	}
+
+	path := f.Name()
+	defer os.Remove(path)
+
+	if _, err := f.WriteString(initial); err != nil {
+		f.Close()
+
+		return composition{}, fmt.Errorf("write temp file: %w", err)
+	}
+
+	if err := f.Close(); err != nil {
+		return composition{}, fmt.Errorf("write temp file: %w", err)
+	}
+
+	if err := runEditor(path); err != nil {
+		return composition{}, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return composition{}, fmt.Errorf("read temp file: %w", err)
+	}
+
+	if string(data) == initial {
+		return composition{}, &ExitError{Code: api.ExitCancelled, Err: fmt.Errorf("%w (file unchanged)", errComposeAborted)}
+	}
+
+	edited := parseComposition(string(data), headers)
+
+	own := edited.Body
+	if quote != "" {
+		own = strings.Replace(own, strings.TrimSpace(quote), "", 1)
+	}
+
+	if !hasOwnText(own) {
+		return composition{}, &ExitError{Code: api.ExitCancelled, Err: fmt.Errorf("%w (empty body)", errComposeAborted)}
+	}
+
+	return edited, nil
+}
+
+// formatComposition renders the editor file: a header block, a blank line
+// and the body. Lines starting with '#' in the header block are ignored.
+func formatComposition(c composition, headers []string, hint, quote string) string {
+	var b strings.Builder
+
+	if hint != "" {
+		for _, line := range strings.Split(hint, "\n") {
+			b.WriteString("# " + line + "\n")
+		}
+	}
+
+	for _, h := range headers {
+		var value string
+
+		switch h {
+		case headerTo:
+			value = strings.Join(c.To, ", ")
+		case headerCc:
+			value = strings.Join(c.Cc, ", ")
+		case headerBcc:
+			value = strings.Join(c.Bcc, ", ")
+		case headerSubject:
+			value = c.Subject
+		case headerChannel:
+			value = c.Channel
+		}
+
+		b.WriteString(strings.TrimRight(h+": "+value, " ") + "\n")
+	}
+
+	if len(headers) > 0 || hint != "" {
+		b.WriteString("\n")
+	}
+
+	b.WriteString(c.Body)
+
+	if quote != "" {
+		if c.Body != "" && !strings.HasSuffix(c.Body, "\n") {
+			b.WriteString("\n")
+		}
+
+		b.WriteString("\n" + quote)
+	}
+
+	return b.String()
+}
+
+// parseComposition reads back a file written by formatComposition.
+// Unknown header names end the header block, so a body that happens to
+// start with "Note: ..." is kept when the command shows no headers.
+func parseComposition(text string, headers []string) composition {
+	var c composition
+
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+
+	i := 0
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		if strings.HasPrefix(line, "#") && len(headers) > 0 {
+			continue
+		}
+
+		if strings.TrimSpace(line) == "" {
+			if len(headers) > 0 {
+				i++
+			}
+
+			break
+		}
+
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			break
+		}
+
+		idx := slices.IndexFunc(headers, func(h string) bool { return strings.EqualFold(h, strings.TrimSpace(name)) })
+		if idx < 0 {
+			break
+		}
+
+		value = strings.TrimSpace(value)
+
+		switch headers[idx] {
+		case headerTo:
+			c.To = splitAddresses(value)
+		case headerCc:
+			c.Cc = splitAddresses(value)
+		case headerBcc:
+			c.Bcc = splitAddresses(value)
+		case headerSubject:
+			c.Subject = value
+		case headerChannel:
+			c.Channel = value
+		}
+	}
+
+	c.Body = strings.TrimSpace(strings.Join(lines[min(i, len(lines)):], "\n"))
+
+	return c
+}
+
+// addComposedFields sets the optional cc, bcc and subject request fields.
+func addComposedFields(req map[string]any, c composition) {
+	if len(c.Cc) > 0 {
+		req["cc"] = c.Cc
+	}
+
+	if len(c.Bcc) > 0 {
+		req["bcc"] = c.Bcc
+	}
+
+	if c.Subject != "" {
+		req["subject"] = c.Subject
+	}
+}
+
+func splitAddresses(value string) []string {
+	var out []string
+
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+
+	return out
+}
+
+// hasOwnText reports whether body has anything besides quoted lines.
+func hasOwnText(body string) bool {
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, ">") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// replyQuote fetches the message being replied to (the given ID, or the
+// latest message in the conversation) and formats it as a quote.
+func replyQuote(ctx context.Context, client *api.Client, convID, messageID string) (string, error) {
+	if messageID == "" {
+		resp, err := client.ListConversationMessages(ctx, convID, 50)
+		if err != nil {
+			return "", err
+		}
+
+		if len(resp.Results) == 0 {
+			return "", nil
+		}
+
+		latest := resp.Results[0]
+		for _, m := range resp.Results[1:] {
+			if m.CreatedAt > latest.CreatedAt {
+				latest = m
+			}
+		}
+
+		messageID = latest.ID
+	}
+
+	msg, err := client.GetMessage(ctx, messageID)
+	if err != nil {
+		return "", err
+	}
+
+	return quoteMessage(msg), nil
+}
+
+func quoteMessage(msg *api.Message) string {
+	text := msg.Text
+	if text == "" {
+		if md, err := markdown.ToMarkdown(msg.Body); err == nil {
+			text = md
+		} else {
+			text = msg.Body
+		}
+	}
+
+	author := "unknown"
+	if msg.Author != nil {
+		author = msg.Author.Email
+		if author == "" {
+			author = msg.Author.Username
+		}
+	} else {
+		for _, r := range msg.Recipients {
+			if r.Role == "from" {
+				author = r.Handle
+
+				break
+			}
+		}
+	}
+
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "On %s, %s wrote:\n", output.FormatTimestamp(msg.CreatedAt), author)
+
+	for _, line := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
+		b.WriteString(strings.TrimRight("> "+line, " ") + "\n")
+	}
+
+	return b.String()
+}
@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"golang.org/x/oauth2"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+)
+
+// stubEditor makes runEditor rewrite the file with edit and records what
+// the editor was opened with.
+func stubEditor(t *testing.T, edit func(string) string) *string {
+	t.Helper()
+
+	var opened string
+
+	old := runEditor
+	runEditor = func(path string) error {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		opened = string(data)
+
+		return os.WriteFile(path, []byte(edit(opened)), 0o600)
+	}
+	t.Cleanup(func() { runEditor = old })
+
+	return &opened
+}
+
+func TestParseCompositionRoundTrip(t *testing.T) {
+	in := composition{
+		To:      []string{"a@example.com", "b@example.com"},
+		Bcc:     []string{"c@example.com"},
+		Subject: "Hello: world",
+		Channel: "Support",
+		Body:    "First line\n\nSecond line",
+	}
+
+	got := parseComposition(formatComposition(in, messageHeaders, "a hint", ""), messageHeaders)
+
+	if strings.Join(got.To, ",") != "a@example.com,b@example.com" || len(got.Cc) != 0 ||
+		strings.Join(got.Bcc, ",") != "c@example.com" {
+		t.Fatalf("recipients = %v %v %v", got.To, got.Cc, got.Bcc)
+	}
+
+	if got.Subject != in.Subject || got.Channel != in.Channel || got.Body != in.Body {
+		t.Fatalf("got %+v", got)
+	}
+}
+
+func TestParseCompositionWithoutHeaders(t *testing.T) {
+	got := parseComposition("Note: call back\n# not a comment\n", nil)
+
+	if got.Body != "Note: call back\n# not a comment" {
+		t.Fatalf("body = %q", got.Body)
+	}
+}
+
+func TestEditCompositionAborts(t *testing.T) {
+	tests := map[string]func(string) string{
+		"unchanged": func(s string) string { return s },
+		"empty":     func(s string) string { return strings.Replace(s, "Subject:", "Subject: hi", 1) },
+		"quote only": func(s string) string {
+			return strings.Replace(s, "To:", "To: a@example.com", 1)
+		},
+	}
+
+	for name, edit := range tests {
+		t.Run(name, func(t *testing.T) {
+			stubEditor(t, edit)
+
+			_, err := editComposition(composition{}, replyHeaders, replyHint, "On x, y wrote:\n> hello\n")
+
+			var exitErr *ExitError
+			if !errors.As(err, &exitErr) || exitErr.Code != api.ExitCancelled {
+				t.Fatalf("err = %v, want ExitCancelled", err)
+			}
+		})
+	}
+}
+
+func TestEditorArgs(t *testing.T) {
+	tests := []struct {
+		editor string
+		want   []string
+	}{
+		{"vi", []string{"vi"}},
+		{"code --wait", []string{"code", "--wait"}},
+		{`"/Applications/Sublime Text.app/Contents/SharedSupport/bin/subl" -w`, []string{"/Applications/Sublime Text.app/Contents/SharedSupport/bin/subl", "-w"}},
+		{`'my editor' --flag="a b"`, []string{"my editor", "--flag=a b"}},
+		{`/opt/my\ editor -n`, []string{"/opt/my editor", "-n"}},
+		{`C:\Windows\notepad.exe`, []string{`C:\Windows\notepad.exe`}},
+	}
+
+	for _, tt := range tests {
+		got, err := editorArgs(tt.editor)
+		if err != nil || !slices.Equal(got, tt.want) {
+			t.Errorf("editorArgs(%q) = %q, %v; want %q", tt.editor, got, err, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"", "  ", `"unterminated -w`} {
+		if _, err := editorArgs(bad); err == nil {
+			t.Errorf("editorArgs(%q): expected an error", bad)
+		}
+	}
+}
+
+func TestEditorArgsExistingPathWithSpaces(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "Sublime Text.app")
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(dir, "subl")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := editorArgs(path)
+	if err != nil || !slices.Equal(got, []string{path}) {
+		t.Fatalf("editorArgs(%q) = %q, %v; want the whole path", path, got, err)
+	}
+}
+
+func TestMsgReplyEditQuotesLatestMessage(t *testing.T) {
+	var gotBody map[string]any
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/conversations/cnv_1/messages":
+			_, _ = io.WriteString(w, `{"_results":[{"id":"msg_old","created_at":1},{"id":"msg_new","created_at":2}]}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/messages/msg_new":
+			_, _ = io.WriteString(w, `{"id":"msg_new","created_at":2,"text":"Can you help?","author":{"email":"cust@example.com"}}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/conversations/cnv_1/messages":
+			if err := json.NewDecoder(r.Body).Decode(&gotBody); err != nil {
+				t.Errorf("decode body: %v", err)
+			}
+
+			_, _ = io.WriteString(w, `{}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	old := newClientFromAuth
+	newClientFromAuth = func(_, _ string) (*api.Client, error) {
+		return api.NewClientWithBaseURL(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}), srv.URL), nil
+	}
+	t.Cleanup(func() { newClientFromAuth = old })
+
+	opened := stubEditor(t, func(s string) string {
+		return strings.Replace(strings.Replace(s, "Cc:", "Cc: boss@example.com", 1), "\n\nOn ", "\nSure thing.\n\nOn ", 1)
+	})
+
+	cmd := MsgReplyCmd{ConvID: "cnv_1", Edit: true}
+	if err := cmd.Run(&RootFlags{Account: "test@example.com", NoCache: true}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if !strings.Contains(*opened, "> Can you help?") || !strings.Contains(*opened, "cust@example.com wrote:") {
+		t.Fatalf("editor file missing quote:\n%s", *opened)
+	}
+
+	// Edited bodies are rendered as markdown, so the quote becomes a
+	// blockquote instead of literal "> " lines.
+	body, _ := gotBody["body"].(string)
+	if !strings.HasPrefix(body, "<p>Sure thing.</p>") || !strings.Contains(body, "<blockquote>\n<p>Can you help?</p>") {
+		t.Fatalf("body = %q", body)
+	}
+
+	if cc, _ := gotBody["cc"].([]any); len(cc) != 1 || cc[0] != "boss@example.com" {
+		t.Fatalf("cc = %v", gotBody["cc"])
+	}
+
+	if _, ok := gotBody["to"]; ok {
+		t.Fatalf("to should be omitted, got %v", gotBody["to"])
+	}
+}
@@ -34,10 +34,16 @@ func (c *ConfigPathCmd) Run() error {
 		return fmt.Errorf("resolve keyring dir: %w", err)
 	}
 
+	cacheDir, err := config.CacheDir()
+	if err != nil {
+		return fmt.Errorf("resolve cache dir: %w", err)
+	}
+
 	fmt.Fprintf(os.Stdout, "Config dir:  %s\n", dir)
 	fmt.Fprintf(os.Stdout, "Config file: %s\n", configPath)
 	fmt.Fprintf(os.Stdout, "Clients dir: %s\n", clientsDir)
 	fmt.Fprintf(os.Stdout, "Keyring dir: %s\n", keyringDir)
+	fmt.Fprintf(os.Stdout, "Cache dir:   %s\n", cacheDir)
 
 	return nil
 }
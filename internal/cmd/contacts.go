@@ -28,6 +28,8 @@ type ContactCmd struct {
 
 type ContactListCmd struct {
 	Limit int `help:"Maximum results" default:"25"`
+
+	PaginationFlags `embed:""`
 }
 
 func (c *ContactListCmd) Run(flags *RootFlags) error {
@@ -43,7 +45,7 @@ func (c *ContactListCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
-	resp, err := client.ListContacts(ctx, c.Limit)
+	resp, err := fetchList[api.Contact](ctx, client, fmt.Sprintf("/contacts?limit=%d", c.Limit), c.PaginationFlags, mode)
 	if err != nil {
 		fmt.Fprint(os.Stderr, errfmt.Format(err))
 
@@ -51,7 +53,7 @@ func (c *ContactListCmd) Run(flags *RootFlags) error {
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, resp)
+		return output.Write(os.Stdout, mode, resp)
 	}
 
 	if len(resp.Results) == 0 {
@@ -60,14 +62,13 @@ func (c *ContactListCmd) Run(flags *RootFlags) error {
 		return nil
 	}
 
-	tbl := output.NewTableWriter(os.Stdout, mode.Plain)
-	tbl.AddRow("ID", "NAME", "HANDLE")
-
-	for _, contact := range resp.Results {
-		tbl.AddRow(output.FormatContact(contact)...)
+	if err := output.WriteRows(os.Stdout, mode, output.ContactColumns, output.ContactFields, resp.Results); err != nil {
+		return err
 	}
 
-	return tbl.Flush()
+	printMoreResultsHint(resp.Pagination.Next)
+
+	return nil
 }
 
 type ContactSearchCmd struct {
@@ -94,38 +95,25 @@ func (c *ContactSearchCmd) Run(flags *RootFlags) error {
 	query := strings.ToLower(c.Query)
 	var matches []api.Contact
 
-	// First page: fetch max 100 contacts
-	resp, err := client.ListContacts(ctx, 100)
-	if err != nil {
-		fmt.Fprint(os.Stderr, errfmt.Format(err))
-		return err
-	}
-
-	for page := 1; page <= c.MaxPages; page++ {
-		for _, contact := range resp.Results {
+	_, err = api.Paginate(ctx, client, "/contacts?limit=100", api.PageOptions{MaxPages: c.MaxPages}, func(page []api.Contact) error {
+		for _, contact := range page {
 			if contactMatches(contact, query) {
 				matches = append(matches, contact)
 				if len(matches) >= c.Limit {
-					break
+					return api.ErrStopPagination
 				}
 			}
 		}
 
-		// Stop if we have enough matches or no more pages
-		if len(matches) >= c.Limit || resp.Pagination.Next == "" {
-			break
-		}
-
-		// Fetch next page
-		resp, err = client.ListContactsPage(ctx, resp.Pagination.Next)
-		if err != nil {
-			fmt.Fprint(os.Stderr, errfmt.Format(err))
-			return err
-		}
+		return nil
+	})
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+		return err
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, matches)
+		return output.Write(os.Stdout, mode, matches)
 	}
 
 	if len(matches) == 0 {
@@ -133,14 +121,7 @@ func (c *ContactSearchCmd) Run(flags *RootFlags) error {
 		return nil
 	}
 
-	tbl := output.NewTableWriter(os.Stdout, mode.Plain)
-	tbl.AddRow("ID", "NAME", "HANDLE")
-
-	for _, contact := range matches {
-		tbl.AddRow(output.FormatContact(contact)...)
-	}
-
-	return tbl.Flush()
+	return output.WriteRows(os.Stdout, mode, output.ContactColumns, output.ContactFields, matches)
 }
 
 func contactMatches(contact api.Contact, query string) bool {
@@ -182,7 +163,11 @@ func (c *ContactGetCmd) Run(flags *RootFlags) error {
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, contact)
+		return output.Write(os.Stdout, mode, contact)
+	}
+
+	if mode.Columnar() {
+		return output.WriteItem(os.Stdout, mode, output.ContactColumns, *contact)
 	}
 
 	fmt.Fprintf(os.Stdout, "ID:   %s\n", contact.ID)
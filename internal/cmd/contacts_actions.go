@@ -65,7 +65,7 @@ func (c *ContactCreateCmd) Run(flags *RootFlags) error {
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, result)
+		return output.Write(os.Stdout, mode, result)
 	}
 
 	fmt.Fprintf(os.Stdout, "Contact created: %s\n", result.ID)
@@ -114,7 +114,7 @@ func (c *ContactUpdateCmd) Run(flags *RootFlags) error {
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, result)
+		return output.Write(os.Stdout, mode, result)
 	}
 
 	fmt.Fprintf(os.Stdout, "Contact updated: %s\n", result.Name)
@@ -13,6 +13,8 @@ import (
 type ContactConvosCmd struct {
 	ID    string `arg:"" help:"Contact ID"`
 	Limit int    `help:"Maximum results" default:"25"`
+
+	PaginationFlags `embed:""`
 }
 
 func (c *ContactConvosCmd) Run(flags *RootFlags) error {
@@ -29,15 +31,15 @@ func (c *ContactConvosCmd) Run(flags *RootFlags) error {
 	}
 
 	path := fmt.Sprintf("/contacts/%s/conversations?limit=%d", c.ID, c.Limit)
-	var resp api.ListResponse[api.Conversation]
-	if err := client.Get(ctx, path, &resp); err != nil {
+	resp, err := fetchList[api.Conversation](ctx, client, path, c.PaginationFlags, mode)
+	if err != nil {
 		fmt.Fprint(os.Stderr, errfmt.Format(err))
 
 		return err
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, resp)
+		return output.Write(os.Stdout, mode, resp)
 	}
 
 	if len(resp.Results) == 0 {
@@ -46,12 +48,11 @@ func (c *ContactConvosCmd) Run(flags *RootFlags) error {
 		return nil
 	}
 
-	tbl := output.NewTableWriter(os.Stdout, mode.Plain)
-	tbl.AddRow("ID", "STATUS", "ASSIGNEE", "SUBJECT", "CREATED")
-
-	for _, conv := range resp.Results {
-		tbl.AddRow(output.FormatConversation(conv)...)
+	if err := output.WriteRows(os.Stdout, mode, output.ConversationColumns, output.ConversationFields, resp.Results); err != nil {
+		return err
 	}
 
-	return tbl.Flush()
+	printMoreResultsHint(resp.Pagination.Next)
+
+	return nil
 }
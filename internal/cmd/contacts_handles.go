@@ -35,7 +35,7 @@ func (c *ContactHandlesCmd) Run(flags *RootFlags) error {
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, map[string]any{"handles": contact.Handles})
+		return output.Write(os.Stdout, mode, map[string]any{"handles": contact.Handles})
 	}
 
 	if len(contact.Handles) == 0 {
@@ -44,14 +44,7 @@ func (c *ContactHandlesCmd) Run(flags *RootFlags) error {
 		return nil
 	}
 
-	tbl := output.NewTableWriter(os.Stdout, mode.Plain)
-	tbl.AddRow("HANDLE", "SOURCE")
-
-	for _, h := range contact.Handles {
-		tbl.AddRow(h.Handle, h.Source)
-	}
-
-	return tbl.Flush()
+	return output.WriteRows(os.Stdout, mode, output.HandleColumns, output.HandleFields, contact.Handles)
 }
 
 type ContactHandleCmd struct {
@@ -91,7 +84,7 @@ func (c *ContactHandleAddCmd) Run(flags *RootFlags) error {
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, result)
+		return output.Write(os.Stdout, mode, result)
 	}
 
 	fmt.Fprintf(os.Stdout, "Handle added: %s\n", result.Handle)
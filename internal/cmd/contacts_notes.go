@@ -35,7 +35,7 @@ func (c *ContactNotesCmd) Run(flags *RootFlags) error {
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, resp)
+		return output.Write(os.Stdout, mode, resp)
 	}
 
 	if len(resp.Results) == 0 {
@@ -44,27 +44,7 @@ func (c *ContactNotesCmd) Run(flags *RootFlags) error {
 		return nil
 	}
 
-	tbl := output.NewTableWriter(os.Stdout, mode.Plain)
-	tbl.AddRow("ID", "AUTHOR", "NOTE", "DATE")
-
-	for _, note := range resp.Results {
-		author := "-"
-		if note.Author != nil {
-			author = note.Author.Email
-			if author == "" {
-				author = note.Author.Username
-			}
-		}
-
-		body := note.Body
-		if len(body) > 50 {
-			body = body[:47] + "..."
-		}
-
-		tbl.AddRow(note.ID, author, body, output.FormatTimestamp(note.CreatedAt))
-	}
-
-	return tbl.Flush()
+	return output.WriteRows(os.Stdout, mode, output.ContactNoteColumns, output.ContactNoteFields, resp.Results)
 }
 
 type ContactNoteCmd struct {
@@ -99,7 +79,7 @@ func (c *ContactNoteAddCmd) Run(flags *RootFlags) error {
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, result)
+		return output.Write(os.Stdout, mode, result)
 	}
 
 	fmt.Fprintf(os.Stdout, "Note added: %s\n", result.ID)
@@ -13,77 +13,44 @@ import (
 )
 
 type ConvArchiveCmd struct {
-	IDs     []string `arg:"" help:"Conversation IDs to archive"`
-	IDsFrom string   `help:"Read conversation IDs from stdin (use '-' for stdin)"`
+	IDs []string `arg:"" optional:"" help:"Conversation IDs to archive"`
+
+	BulkFlags `embed:""`
 }
 
 func (c *ConvArchiveCmd) Run(flags *RootFlags) error {
-	ctx := context.Background()
-
-	client, err := getClient(flags)
-	if err != nil {
-		return err
-	}
-
-	ids, err := collectIDs(c.IDs, c.IDsFrom)
-	if err != nil {
-		return err
-	}
-
-	if len(ids) == 0 {
-		return fmt.Errorf("no conversation IDs provided")
-	}
-
-	for _, id := range ids {
-		if err := client.Patch(ctx, "/conversations/"+id, map[string]string{"status": "archived"}, nil); err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to archive %s: %v\n", id, err)
-		} else {
-			fmt.Fprintf(os.Stdout, "Archived %s\n", id)
-		}
-	}
-
-	return nil
+	return setConversationStatus(flags, c.IDs, c.BulkFlags, "archived", bulkAction{verb: "archive", done: "Archived %s"})
 }
 
 type ConvOpenCmd struct {
-	IDs     []string `arg:"" help:"Conversation IDs to open"`
-	IDsFrom string   `help:"Read conversation IDs from stdin (use '-' for stdin)"`
+	IDs []string `arg:"" optional:"" help:"Conversation IDs to open"`
+
+	BulkFlags `embed:""`
 }
 
 func (c *ConvOpenCmd) Run(flags *RootFlags) error {
-	ctx := context.Background()
-
-	client, err := getClient(flags)
-	if err != nil {
-		return err
-	}
-
-	ids, err := collectIDs(c.IDs, c.IDsFrom)
-	if err != nil {
-		return err
-	}
+	return setConversationStatus(flags, c.IDs, c.BulkFlags, "open", bulkAction{verb: "open", done: "Opened %s"})
+}
 
-	if len(ids) == 0 {
-		return fmt.Errorf("no conversation IDs provided")
-	}
+type ConvTrashCmd struct {
+	IDs []string `arg:"" optional:"" help:"Conversation IDs to trash"`
 
-	for _, id := range ids {
-		if err := client.Patch(ctx, "/conversations/"+id, map[string]string{"status": "open"}, nil); err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to open %s: %v\n", id, err)
-		} else {
-			fmt.Fprintf(os.Stdout, "Opened %s\n", id)
-		}
-	}
+	BulkFlags `embed:""`
+}
 
-	return nil
+func (c *ConvTrashCmd) Run(flags *RootFlags) error {
+	return setConversationStatus(flags, c.IDs, c.BulkFlags, "trashed", bulkAction{verb: "trash", done: "Trashed %s"})
 }
 
-type ConvTrashCmd struct {
-	IDs     []string `arg:"" help:"Conversation IDs to trash"`
-	IDsFrom string   `help:"Read conversation IDs from stdin (use '-' for stdin)"`
+func setConversationStatus(flags *RootFlags, args []string, bulk BulkFlags, status string, action bulkAction) error {
+	req := map[string]string{"status": status}
+
+	return patchConversations(flags, args, bulk, "", req, action)
 }
 
-func (c *ConvTrashCmd) Run(flags *RootFlags) error {
+// patchConversations sends req as a PATCH to every conversation, or to its
+// suffix subresource, and reports the results.
+func patchConversations(flags *RootFlags, args []string, bulk BulkFlags, suffix string, req any, action bulkAction) error {
 	ctx := context.Background()
 
 	client, err := getClient(flags)
@@ -91,29 +58,28 @@ func (c *ConvTrashCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
-	ids, err := collectIDs(c.IDs, c.IDsFrom)
+	mode, err := resolveOutputMode(flags)
 	if err != nil {
 		return err
 	}
 
-	if len(ids) == 0 {
-		return fmt.Errorf("no conversation IDs provided")
+	ids, err := bulk.ids(args...)
+	if err != nil {
+		return err
 	}
 
-	for _, id := range ids {
-		if err := client.Patch(ctx, "/conversations/"+id, map[string]string{"status": "trashed"}, nil); err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to trash %s: %v\n", id, err)
-		} else {
-			fmt.Fprintf(os.Stdout, "Trashed %s\n", id)
-		}
-	}
+	results := runBulk(ctx, ids, bulk, func(ctx context.Context, id string) error {
+		return client.Patch(ctx, "/conversations/"+id+suffix, req, nil)
+	})
 
-	return nil
+	return reportBulk(mode, action, results)
 }
 
 type ConvAssignCmd struct {
-	ID string `arg:"" help:"Conversation ID"`
-	To string `required:"" help:"Teammate ID to assign to"`
+	IDs []string `arg:"" optional:"" help:"Conversation IDs"`
+	To  string   `required:"" help:"Teammate to assign to (ID, email, name or me)"`
+
+	BulkFlags `embed:""`
 }
 
 func (c *ConvAssignCmd) Run(flags *RootFlags) error {
@@ -124,54 +90,37 @@ func (c *ConvAssignCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
-	if err := client.Patch(ctx, "/conversations/"+c.ID, map[string]string{"assignee_id": c.To}, nil); err != nil {
-		fmt.Fprint(os.Stderr, errfmt.Format(err))
-
+	assigneeID, err := newResolver(client, flags).teammateID(ctx, c.To)
+	if err != nil {
 		return err
 	}
 
-	fmt.Fprintf(os.Stdout, "Assigned %s to %s\n", c.ID, c.To)
+	req := map[string]string{"assignee_id": assigneeID}
 
-	return nil
+	return patchConversations(flags, c.IDs, c.BulkFlags, "", req, bulkAction{verb: "assign", done: "Assigned %s to " + assigneeID})
 }
 
 type ConvUnassignCmd struct {
-	ID string `arg:"" help:"Conversation ID"`
+	IDs []string `arg:"" optional:"" help:"Conversation IDs"`
+
+	BulkFlags `embed:""`
 }
 
 func (c *ConvUnassignCmd) Run(flags *RootFlags) error {
-	ctx := context.Background()
+	req := map[string]any{"assignee_id": nil}
 
-	client, err := getClient(flags)
-	if err != nil {
-		return err
-	}
-
-	if err := client.Patch(ctx, "/conversations/"+c.ID, map[string]any{"assignee_id": nil}, nil); err != nil {
-		fmt.Fprint(os.Stderr, errfmt.Format(err))
-
-		return err
-	}
-
-	fmt.Fprintf(os.Stdout, "Unassigned %s\n", c.ID)
-
-	return nil
+	return patchConversations(flags, c.IDs, c.BulkFlags, "", req, bulkAction{verb: "unassign", done: "Unassigned %s"})
 }
 
 type ConvSnoozeCmd struct {
-	ID       string `arg:"" help:"Conversation ID"`
-	Until    string `help:"Snooze until (RFC3339 timestamp)"`
-	Duration string `help:"Snooze duration (e.g. 2h, 30m)"`
+	IDs      []string `arg:"" optional:"" help:"Conversation IDs"`
+	Until    string   `help:"Snooze until (RFC3339 timestamp)"`
+	Duration string   `help:"Snooze duration (e.g. 2h, 30m)"`
+
+	BulkFlags `embed:""`
 }
 
 func (c *ConvSnoozeCmd) Run(flags *RootFlags) error {
-	ctx := context.Background()
-
-	client, err := getClient(flags)
-	if err != nil {
-		return err
-	}
-
 	until := strings.TrimSpace(c.Until)
 	if strings.TrimSpace(c.Duration) != "" {
 		if until != "" {
@@ -192,15 +141,7 @@ func (c *ConvSnoozeCmd) Run(flags *RootFlags) error {
 
 	req := map[string]string{"scheduled_at": until}
 
-	if err := client.Patch(ctx, fmt.Sprintf("/conversations/%s/reminders", c.ID), req, nil); err != nil {
-		fmt.Fprint(os.Stderr, errfmt.Format(err))
-
-		return err
-	}
-
-	fmt.Fprintf(os.Stdout, "Snoozed %s until %s\n", c.ID, until)
-
-	return nil
+	return patchConversations(flags, c.IDs, c.BulkFlags, "/reminders", req, bulkAction{verb: "snooze", done: "Snoozed %s until " + until})
 }
 
 type ConvUnsnoozeCmd struct {
@@ -253,7 +194,7 @@ func (c *ConvFollowersCmd) Run(flags *RootFlags) error {
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, resp)
+		return output.Write(os.Stdout, mode, resp)
 	}
 
 	if len(resp.Results) == 0 {
@@ -262,19 +203,14 @@ func (c *ConvFollowersCmd) Run(flags *RootFlags) error {
 		return nil
 	}
 
-	tbl := output.NewTableWriter(os.Stdout, mode.Plain)
-	tbl.AddRow("ID", "EMAIL", "NAME")
-
-	for _, tm := range resp.Results {
-		tbl.AddRow(output.FormatTeammate(tm)...)
-	}
-
-	return tbl.Flush()
+	return output.WriteRows(os.Stdout, mode, output.TeammateColumns, output.TeammateFields, resp.Results)
 }
 
 type ConvFollowCmd struct {
-	ID   string `arg:"" help:"Conversation ID"`
-	User string `help:"Teammate ID to follow as"`
+	IDs  []string `arg:"" optional:"" help:"Conversation IDs"`
+	User string   `help:"Teammate to follow as (ID, email, name or me)"`
+
+	BulkFlags `embed:""`
 }
 
 func (c *ConvFollowCmd) Run(flags *RootFlags) error {
@@ -285,29 +221,41 @@ func (c *ConvFollowCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
-	var body map[string]string
-	if strings.TrimSpace(c.User) != "" {
-		body = map[string]string{"teammate_id": c.User}
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
 	}
 
-	if err := client.Post(ctx, fmt.Sprintf("/conversations/%s/followers", c.ID), body, nil); err != nil {
-		fmt.Fprint(os.Stderr, errfmt.Format(err))
+	ids, err := c.ids(c.IDs...)
+	if err != nil {
+		return err
+	}
 
+	user, err := newResolver(client, flags).teammateID(ctx, c.User)
+	if err != nil {
 		return err
 	}
 
-	if c.User != "" {
-		fmt.Fprintf(os.Stdout, "Added follower %s to %s\n", c.User, c.ID)
-	} else {
-		fmt.Fprintf(os.Stdout, "Followed %s\n", c.ID)
+	var body map[string]string
+	if user != "" {
+		body = map[string]string{"teammate_id": user}
 	}
 
-	return nil
+	results := runBulk(ctx, ids, c.BulkFlags, func(ctx context.Context, id string) error {
+		return client.Post(ctx, fmt.Sprintf("/conversations/%s/followers", id), body, nil)
+	})
+
+	action := bulkAction{verb: "follow", done: "Followed %s"}
+	if user != "" {
+		action = bulkAction{verb: "add follower to", done: "Added follower " + user + " to %s"}
+	}
+
+	return reportBulk(mode, action, results)
 }
 
 type ConvUnfollowCmd struct {
 	ID   string `arg:"" help:"Conversation ID"`
-	User string `help:"Teammate ID to unfollow"`
+	User string `help:"Teammate to unfollow (ID, email, name or me)"`
 }
 
 func (c *ConvUnfollowCmd) Run(flags *RootFlags) error {
@@ -318,9 +266,14 @@ func (c *ConvUnfollowCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
+	user, err := newResolver(client, flags).teammateID(ctx, c.User)
+	if err != nil {
+		return err
+	}
+
 	path := fmt.Sprintf("/conversations/%s/followers", c.ID)
-	if strings.TrimSpace(c.User) != "" {
-		path = fmt.Sprintf("/conversations/%s/followers/%s", c.ID, c.User)
+	if user != "" {
+		path = fmt.Sprintf("/conversations/%s/followers/%s", c.ID, user)
 	}
 
 	if err := client.Delete(ctx, path); err != nil {
@@ -329,8 +282,8 @@ func (c *ConvUnfollowCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
-	if c.User != "" {
-		fmt.Fprintf(os.Stdout, "Removed follower %s from %s\n", c.User, c.ID)
+	if user != "" {
+		fmt.Fprintf(os.Stdout, "Removed follower %s from %s\n", user, c.ID)
 	} else {
 		fmt.Fprintf(os.Stdout, "Unfollowed %s\n", c.ID)
 	}
@@ -339,8 +292,10 @@ func (c *ConvUnfollowCmd) Run(flags *RootFlags) error {
 }
 
 type ConvTagCmd struct {
-	ID    string `arg:"" help:"Conversation ID"`
-	TagID string `arg:"" help:"Tag ID to add"`
+	IDs []string `arg:"" optional:"" help:"Conversation IDs"`
+	Tag string   `help:"Tag to add (ID or name)" required:""`
+
+	BulkFlags `embed:""`
 }
 
 func (c *ConvTagCmd) Run(flags *RootFlags) error {
@@ -351,21 +306,35 @@ func (c *ConvTagCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
-	payload := map[string][]string{"tag_ids": {c.TagID}}
-	if err := client.Post(ctx, fmt.Sprintf("/conversations/%s/tags", c.ID), payload, nil); err != nil {
-		fmt.Fprint(os.Stderr, errfmt.Format(err))
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
 
+	ids, err := c.ids(c.IDs...)
+	if err != nil {
 		return err
 	}
 
-	fmt.Fprintf(os.Stdout, "Tagged %s with %s\n", c.ID, c.TagID)
+	tagID, err := newResolver(client, flags).tagID(ctx, c.Tag)
+	if err != nil {
+		return err
+	}
 
-	return nil
+	payload := map[string][]string{"tag_ids": {tagID}}
+
+	results := runBulk(ctx, ids, c.BulkFlags, func(ctx context.Context, id string) error {
+		return client.Post(ctx, fmt.Sprintf("/conversations/%s/tags", id), payload, nil)
+	})
+
+	return reportBulk(mode, bulkAction{verb: "tag", done: "Tagged %s with " + tagID}, results)
 }
 
 type ConvUntagCmd struct {
-	ID    string `arg:"" help:"Conversation ID"`
-	TagID string `arg:"" help:"Tag ID to remove"`
+	IDs []string `arg:"" optional:"" help:"Conversation IDs"`
+	Tag string   `help:"Tag to remove (ID or name)" required:""`
+
+	BulkFlags `embed:""`
 }
 
 func (c *ConvUntagCmd) Run(flags *RootFlags) error {
@@ -376,30 +345,36 @@ func (c *ConvUntagCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
-	if err := client.Delete(ctx, fmt.Sprintf("/conversations/%s/tags/%s", c.ID, c.TagID)); err != nil {
-		fmt.Fprint(os.Stderr, errfmt.Format(err))
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	ids, err := c.ids(c.IDs...)
+	if err != nil {
+		return err
+	}
 
+	tagID, err := newResolver(client, flags).tagID(ctx, c.Tag)
+	if err != nil {
 		return err
 	}
 
-	fmt.Fprintf(os.Stdout, "Untagged %s from %s\n", c.TagID, c.ID)
+	results := runBulk(ctx, ids, c.BulkFlags, func(ctx context.Context, id string) error {
+		return client.Delete(ctx, fmt.Sprintf("/conversations/%s/tags/%s", id, tagID))
+	})
 
-	return nil
+	return reportBulk(mode, bulkAction{verb: "untag", done: "Untagged " + tagID + " from %s"}, results)
 }
 
 type ConvUpdateCmd struct {
-	ID     string   `arg:"" help:"Conversation ID"`
+	IDs    []string `arg:"" optional:"" help:"Conversation IDs"`
 	Fields []string `help:"Custom field update (key=value)" name:"field"`
+
+	BulkFlags `embed:""`
 }
 
 func (c *ConvUpdateCmd) Run(flags *RootFlags) error {
-	ctx := context.Background()
-
-	client, err := getClient(flags)
-	if err != nil {
-		return err
-	}
-
 	if len(c.Fields) == 0 {
 		return fmt.Errorf("at least one --field key=value is required")
 	}
@@ -424,15 +399,7 @@ func (c *ConvUpdateCmd) Run(flags *RootFlags) error {
 		"custom_fields": customFields,
 	}
 
-	if err := client.Patch(ctx, "/conversations/"+c.ID, req, nil); err != nil {
-		fmt.Fprint(os.Stderr, errfmt.Format(err))
-
-		return err
-	}
-
-	fmt.Fprintf(os.Stdout, "Updated %s\n", c.ID)
-
-	return nil
+	return patchConversations(flags, c.IDs, c.BulkFlags, "", req, bulkAction{verb: "update", done: "Updated %s"})
 }
 
 func collectIDs(ids []string, idsFrom string) ([]string, error) {
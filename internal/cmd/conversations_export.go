@@ -0,0 +1,346 @@
+package cmd
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+	"github.com/dedene/frontapp-cli/internal/export"
+	"github.com/dedene/frontapp-cli/internal/output"
+)
+
+type ConvExportCmd struct {
+	IDs           []string `arg:"" optional:"" help:"Conversation IDs to export"`
+	IDsFrom       string   `help:"Read conversation IDs from stdin (use '-' for stdin)" name:"ids-from"`
+	Format        string   `help:"Export format: eml (one file per message), mbox (one file per conversation), json or markdown" enum:"eml,mbox,json,markdown" default:"eml"`
+	Out           string   `help:"Output directory" required:"" type:"path"`
+	NoAttachments bool     `help:"Leave attachments out of eml and mbox exports" name:"no-attachments"`
+}
+
+// exportResult is printed for every exported conversation.
+type exportResult struct {
+	ConversationID string   `json:"conversation_id"`
+	Format         string   `json:"format"`
+	Files          []string `json:"files,omitempty"`
+	Error          string   `json:"error,omitempty"`
+}
+
+// conversationExport is everything fetched for one conversation.
+type conversationExport struct {
+	Conversation *api.Conversation `json:"conversation"`
+	Messages     []api.Message     `json:"messages"`
+	Comments     []api.Comment     `json:"comments"`
+
+	attachments map[string][]byte
+}
+
+func (c *ConvExportCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	ids, err := collectIDs(c.IDs, c.IDsFrom)
+	if err != nil {
+		return err
+	}
+
+	if len(ids) == 0 {
+		return fmt.Errorf("no conversation IDs provided")
+	}
+
+	if err := os.MkdirAll(c.Out, 0o700); err != nil {
+		return fmt.Errorf("create output dir: %w", err)
+	}
+
+	results := make([]exportResult, 0, len(ids))
+	failed := 0
+
+	for _, id := range ids {
+		files, err := c.export(ctx, client, mode, id)
+
+		result := exportResult{ConversationID: id, Format: c.Format, Files: files}
+		if err != nil {
+			failed++
+			result.Error = err.Error()
+
+			fmt.Fprintf(os.Stderr, "Failed to export %s: %v\n", id, err)
+		} else if !mode.JSON {
+			fmt.Fprintf(os.Stdout, "Exported %s (%d file(s))\n", id, len(files))
+		}
+
+		results = append(results, result)
+	}
+
+	if mode.JSON {
+		// With --format json the --jq filter shapes the exported files, so
+		// the summary is printed unfiltered.
+		summary := mode
+		if c.Format == "json" {
+			summary.JQ = nil
+		}
+
+		if err := output.Write(os.Stdout, summary, results); err != nil {
+			return err
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d conversation(s) failed to export", failed, len(ids))
+	}
+
+	return nil
+}
+
+func (c *ConvExportCmd) export(ctx context.Context, client *api.Client, mode output.Mode, id string) ([]string, error) {
+	data, err := fetchConversationExport(ctx, client, id, !c.NoAttachments && (c.Format == "eml" || c.Format == "mbox"))
+	if err != nil {
+		return nil, err
+	}
+
+	name := sanitizeFilename(id, "conversation")
+
+	switch c.Format {
+	case "json":
+		var buf bytes.Buffer
+		if err := output.WriteJSON(&buf, mode, data); err != nil {
+			return nil, err
+		}
+
+		return writeExportFile(filepath.Join(c.Out, name+".json"), buf.Bytes())
+	case "markdown":
+		var buf bytes.Buffer
+
+		fmt.Fprintf(&buf, "# %s\n\n", data.Conversation.Subject)
+		fmt.Fprintf(&buf, "- ID: %s\n- Status: %s\n- Created: %s\n", id, data.Conversation.Status, output.FormatTimestamp(data.Conversation.CreatedAt))
+		(&ConvGetCmd{ID: id}).renderTimeline(&buf, data.Messages, data.Comments)
+
+		return writeExportFile(filepath.Join(c.Out, name+".md"), buf.Bytes())
+	case "mbox":
+		var buf bytes.Buffer
+
+		mbox := export.NewMbox(&buf)
+		for _, e := range data.emails() {
+			if err := mbox.Add(e); err != nil {
+				return nil, err
+			}
+		}
+
+		return writeExportFile(filepath.Join(c.Out, name+".mbox"), buf.Bytes())
+	default:
+		dir := filepath.Join(c.Out, name)
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return nil, fmt.Errorf("create output dir: %w", err)
+		}
+
+		var files []string
+
+		for i, e := range data.emails() {
+			var buf bytes.Buffer
+			if err := export.WriteEML(&buf, e); err != nil {
+				return files, err
+			}
+
+			id := e.Headers["X-Front-Message-Id"] + e.Headers["X-Front-Comment-Id"]
+
+			written, err := writeExportFile(filepath.Join(dir, fmt.Sprintf("%03d-%s.eml", i+1, sanitizeFilename(id, "message"))), buf.Bytes())
+			if err != nil {
+				return files, err
+			}
+
+			files = append(files, written...)
+		}
+
+		return files, nil
+	}
+}
+
+func writeExportFile(path string, data []byte) ([]string, error) {
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return nil, fmt.Errorf("write %s: %w", path, err)
+	}
+
+	return []string{path}, nil
+}
+
+// fetchConversationExport fetches the conversation with its full messages,
+// comments and, when withAttachments is set, the content of every attachment.
+func fetchConversationExport(ctx context.Context, client *api.Client, id string, withAttachments bool) (*conversationExport, error) {
+	conv, err := client.GetConversation(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	get := &ConvGetCmd{ID: id}
+	data := &conversationExport{Conversation: conv, attachments: make(map[string][]byte)}
+
+	g, gctx := errgroup.WithContext(ctx)
+
+	g.Go(func() error {
+		var err error
+		data.Messages, err = get.fetchFullMessages(gctx, client)
+
+		return err
+	})
+
+	g.Go(func() error {
+		var err error
+		data.Comments, err = get.fetchComments(gctx, client)
+
+		return err
+	})
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	slices.SortStableFunc(data.Messages, func(a, b api.Message) int { return cmp.Compare(a.CreatedAt, b.CreatedAt) })
+	slices.SortStableFunc(data.Comments, func(a, b api.Comment) int { return cmp.Compare(a.PostedAt, b.PostedAt) })
+
+	if !withAttachments {
+		return data, nil
+	}
+
+	for _, msg := range data.Messages {
+		for _, att := range msg.Attachments {
+			attID := attachmentID(att)
+
+			var buf bytes.Buffer
+			if err := client.Download(ctx, "/download/"+attID, &buf); err != nil {
+				return nil, fmt.Errorf("download attachment %s: %w", att.Filename, err)
+			}
+
+			data.attachments[attID] = buf.Bytes()
+		}
+	}
+
+	return data, nil
+}
+
+// emails converts messages and comments to emails in chronological order.
+// Comments become messages from their author with an X-Front-Comment-Id
+// header, so internal discussion is preserved next to the customer thread.
+func (d *conversationExport) emails() []export.Email {
+	type dated struct {
+		at    float64
+		email export.Email
+	}
+
+	items := make([]dated, 0, len(d.Messages)+len(d.Comments))
+
+	for _, msg := range d.Messages {
+		items = append(items, dated{at: msg.CreatedAt, email: d.messageEmail(msg)})
+	}
+
+	for _, com := range d.Comments {
+		items = append(items, dated{at: com.PostedAt, email: d.commentEmail(com)})
+	}
+
+	slices.SortStableFunc(items, func(a, b dated) int { return cmp.Compare(a.at, b.at) })
+
+	out := make([]export.Email, len(items))
+	for i, item := range items {
+		out[i] = item.email
+	}
+
+	return out
+}
+
+func (d *conversationExport) messageEmail(msg api.Message) export.Email {
+	direction := "outbound"
+	if msg.IsInbound {
+		direction = "inbound"
+	}
+
+	e := export.Email{
+		Subject:   msg.Subject,
+		Date:      unixTime(msg.CreatedAt),
+		MessageID: msg.ID + "@frontapp.com",
+		Text:      msg.Text,
+		HTML:      msg.Body,
+		Headers: map[string]string{
+			"X-Front-Conversation-Id": d.Conversation.ID,
+			"X-Front-Message-Id":      msg.ID,
+			"X-Front-Message-Type":    msg.Type,
+			"X-Front-Direction":       direction,
+		},
+	}
+
+	if e.Subject == "" {
+		e.Subject = d.Conversation.Subject
+	}
+
+	for _, r := range msg.Recipients {
+		switch r.Role {
+		case "from":
+			e.From = r.Handle
+		case "to":
+			e.To = append(e.To, r.Handle)
+		case "cc":
+			e.Cc = append(e.Cc, r.Handle)
+		case "bcc":
+			e.Bcc = append(e.Bcc, r.Handle)
+		}
+	}
+
+	if e.From == "" && msg.Author != nil {
+		e.From = msg.Author.Email
+	}
+
+	for _, att := range msg.Attachments {
+		if content, ok := d.attachments[attachmentID(att)]; ok {
+			e.Attachments = append(e.Attachments, export.Attachment{
+				Filename:    att.Filename,
+				ContentType: att.ContentType,
+				Data:        content,
+			})
+		}
+	}
+
+	return e
+}
+
+func (d *conversationExport) commentEmail(com api.Comment) export.Email {
+	e := export.Email{
+		Subject:   "[Comment] " + d.Conversation.Subject,
+		Date:      unixTime(com.PostedAt),
+		MessageID: com.ID + "@frontapp.com",
+		Text:      com.Body,
+		Headers: map[string]string{
+			"X-Front-Conversation-Id": d.Conversation.ID,
+			"X-Front-Comment-Id":      com.ID,
+		},
+	}
+
+	if com.Author != nil {
+		e.From = com.Author.Email
+	}
+
+	return e
+}
+
+func unixTime(ts float64) time.Time {
+	if ts == 0 {
+		return time.Time{}
+	}
+
+	sec, frac := math.Modf(ts)
+
+	return time.Unix(int64(sec), int64(frac*1e9)).UTC()
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/oauth2"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+)
+
+func exportTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/conversations/cnv_1":
+			_, _ = io.WriteString(w, `{"id":"cnv_1","subject":"Refund","status":"archived","created_at":1700000000}`)
+		case "/conversations/cnv_1/messages":
+			_, _ = io.WriteString(w, `{"_results":[{"id":"msg_2","created_at":1700000200},{"id":"msg_1","created_at":1700000100}]}`)
+		case "/messages/msg_1":
+			_, _ = io.WriteString(w, `{"id":"msg_1","created_at":1700000100,"is_inbound":true,"text":"I want a refund","body":"<p>I want a refund</p>",
+				"recipients":[{"handle":"cust@example.com","role":"from"},{"handle":"support@example.com","role":"to"}],
+				"attachments":[{"id":"fil_1","filename":"receipt.pdf","content_type":"application/pdf"}]}`)
+		case "/messages/msg_2":
+			_, _ = io.WriteString(w, `{"id":"msg_2","created_at":1700000200,"text":"Done","body":"<p>Done</p>",
+				"recipients":[{"handle":"support@example.com","role":"from"},{"handle":"cust@example.com","role":"to"}]}`)
+		case "/conversations/cnv_1/comments":
+			_, _ = io.WriteString(w, `{"_results":[{"id":"com_1","body":"Approved","posted_at":1700000150,"author":{"email":"lead@example.com"}}]}`)
+		case "/download/fil_1":
+			_, _ = io.WriteString(w, "%PDF")
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	old := newClientFromAuth
+	newClientFromAuth = func(_, _ string) (*api.Client, error) {
+		return api.NewClientWithBaseURL(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}), srv.URL), nil
+	}
+	t.Cleanup(func() { newClientFromAuth = old })
+
+	return srv
+}
+
+func TestConvExportEML(t *testing.T) {
+	exportTestServer(t)
+
+	out := t.TempDir()
+	cmd := ConvExportCmd{IDs: []string{"cnv_1"}, Format: "eml", Out: out}
+
+	if err := cmd.Run(&RootFlags{Account: "test@example.com", NoCache: true}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	entries, err := os.ReadDir(filepath.Join(out, "cnv_1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+
+	if strings.Join(names, ",") != "001-msg_1.eml,002-com_1.eml,003-msg_2.eml" {
+		t.Fatalf("files = %v", names)
+	}
+
+	data, _ := os.ReadFile(filepath.Join(out, "cnv_1", "001-msg_1.eml"))
+	for _, want := range []string{"From: cust@example.com\r\n", "Subject: Refund\r\n", "X-Front-Direction: inbound\r\n", `filename=receipt.pdf`, "JVBERg=="} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("eml missing %q:\n%s", want, data)
+		}
+	}
+}
+
+func TestConvExportMarkdown(t *testing.T) {
+	exportTestServer(t)
+
+	out := t.TempDir()
+	cmd := ConvExportCmd{IDs: []string{"cnv_1"}, Format: "markdown", Out: out}
+
+	if err := cmd.Run(&RootFlags{Account: "test@example.com", NoCache: true}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(out, "cnv_1.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	text := string(data)
+	if !strings.HasPrefix(text, "# Refund\n") || strings.Index(text, "I want a refund") > strings.Index(text, "Approved") {
+		t.Fatalf("markdown export:\n%s", text)
+	}
+}
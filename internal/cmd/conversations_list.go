@@ -3,6 +3,7 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/url"
 	"os"
 	"strings"
@@ -17,11 +18,13 @@ import (
 )
 
 type ConvListCmd struct {
-	Inbox     string `help:"Filter by inbox ID"`
-	Tag       string `help:"Filter by tag ID"`
+	Inbox     string `help:"Filter by inbox (ID or name)"`
+	Tag       string `help:"Filter by tag (ID or name)"`
 	Status    string `help:"Filter by status (open, assigned, unassigned, archived, snoozed, trashed)"`
 	Limit     int    `help:"Maximum number of results" default:"25"`
 	SortOrder string `help:"Sort order (asc, desc)" short:"s" enum:"asc,desc,-" default:"-"`
+
+	PaginationFlags `embed:""`
 }
 
 func (c *ConvListCmd) Run(flags *RootFlags) error {
@@ -37,13 +40,27 @@ func (c *ConvListCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
-	resp, err := client.ListConversations(ctx, api.ListConversationsOptions{
-		InboxID:   c.Inbox,
-		TagID:     c.Tag,
+	res := newResolver(client, flags)
+
+	inboxID, err := res.inboxID(ctx, c.Inbox)
+	if err != nil {
+		return err
+	}
+
+	tagID, err := res.tagID(ctx, c.Tag)
+	if err != nil {
+		return err
+	}
+
+	opts := api.ListConversationsOptions{
+		InboxID:   inboxID,
+		TagID:     tagID,
 		Statuses:  api.ParseStatus(c.Status),
 		Limit:     c.Limit,
 		SortOrder: c.SortOrder,
-	})
+	}
+
+	resp, err := fetchList[api.Conversation](ctx, client, "/conversations?"+opts.Query(), c.PaginationFlags, mode)
 	if err != nil {
 		fmt.Fprint(os.Stderr, errfmt.Format(err))
 
@@ -51,7 +68,7 @@ func (c *ConvListCmd) Run(flags *RootFlags) error {
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, resp)
+		return output.Write(os.Stdout, mode, resp)
 	}
 
 	if len(resp.Results) == 0 {
@@ -60,14 +77,13 @@ func (c *ConvListCmd) Run(flags *RootFlags) error {
 		return nil
 	}
 
-	tbl := output.NewTableWriter(os.Stdout, mode.Plain)
-	tbl.AddRow("ID", "STATUS", "ASSIGNEE", "SUBJECT", "CREATED", "UPDATED")
-
-	for _, conv := range resp.Results {
-		tbl.AddRow(output.FormatConversationWithUpdated(conv)...)
+	if err := output.WriteRows(os.Stdout, mode, output.ConversationColumns, output.ConversationFieldsWithUpdated, resp.Results); err != nil {
+		return err
 	}
 
-	return tbl.Flush()
+	printMoreResultsHint(resp.Pagination.Next)
+
+	return nil
 }
 
 type ConvGetCmd struct {
@@ -124,7 +140,11 @@ func (c *ConvGetCmd) Run(flags *RootFlags) error {
 			result["comments"] = comments
 		}
 
-		return output.WriteJSON(os.Stdout, result)
+		return output.Write(os.Stdout, mode, result)
+	}
+
+	if mode.Columnar() {
+		return output.WriteItem(os.Stdout, mode, output.ConversationColumns, *conv)
 	}
 
 	fmt.Fprintf(os.Stdout, "ID:       %s\n", conv.ID)
@@ -158,14 +178,7 @@ func (c *ConvGetCmd) Run(flags *RootFlags) error {
 
 		fmt.Fprintln(os.Stdout, "\nMessages:")
 
-		tbl := output.NewTableWriter(os.Stdout, mode.Plain)
-		tbl.AddRow("ID", "DIR", "FROM", "PREVIEW", "DATE")
-
-		for _, msg := range msgs.Results {
-			tbl.AddRow(output.FormatMessage(msg)...)
-		}
-
-		if err := tbl.Flush(); err != nil {
+		if err := output.WriteRows(os.Stdout, mode, output.MessageColumns, output.MessageFields, msgs.Results); err != nil {
 			return err
 		}
 	}
@@ -181,32 +194,7 @@ func (c *ConvGetCmd) Run(flags *RootFlags) error {
 		if len(comments) == 0 {
 			fmt.Fprintln(os.Stdout, "No comments found.")
 		} else {
-			tbl := output.NewTableWriter(os.Stdout, mode.Plain)
-			tbl.AddRow("ID", "AUTHOR", "BODY", "DATE")
-
-			for _, comment := range comments {
-				author := "-"
-				if comment.Author != nil {
-					author = comment.Author.Email
-					if author == "" {
-						author = comment.Author.Username
-					}
-				}
-
-				body := comment.Body
-				if len(body) > 50 {
-					body = body[:47] + "..."
-				}
-
-				tbl.AddRow(
-					comment.ID,
-					author,
-					body,
-					output.FormatTimestamp(comment.PostedAt),
-				)
-			}
-
-			if err := tbl.Flush(); err != nil {
+			if err := output.WriteRows(os.Stdout, mode, output.CommentColumns, output.CommentFields, comments); err != nil {
 				return err
 			}
 		}
@@ -287,6 +275,12 @@ type timelineItem struct {
 }
 
 func (c *ConvGetCmd) printFullTimeline(ctx context.Context, client *api.Client) error {
+	return c.writeFullTimeline(ctx, client, os.Stdout)
+}
+
+// writeFullTimeline fetches the full messages and comments of the
+// conversation and renders them as a timeline to w.
+func (c *ConvGetCmd) writeFullTimeline(ctx context.Context, client *api.Client, w io.Writer) error {
 	// Fetch messages and comments in parallel
 	var messages []api.Message
 	var comments []api.Comment
@@ -311,10 +305,18 @@ func (c *ConvGetCmd) printFullTimeline(ctx context.Context, client *api.Client)
 		return err
 	}
 
+	c.renderTimeline(w, messages, comments)
+
+	return nil
+}
+
+// renderTimeline writes messages and comments interleaved in chronological
+// order to w.
+func (c *ConvGetCmd) renderTimeline(w io.Writer, messages []api.Message, comments []api.Comment) {
 	if len(messages) == 0 && len(comments) == 0 {
-		fmt.Fprintln(os.Stdout, "\nNo messages or comments.")
+		fmt.Fprintln(w, "\nNo messages or comments.")
 
-		return nil
+		return
 	}
 
 	// Build timeline
@@ -336,21 +338,19 @@ func (c *ConvGetCmd) printFullTimeline(ctx context.Context, client *api.Client)
 	// Sort by timestamp (chronological order)
 	sortTimeline(timeline)
 
-	fmt.Fprintln(os.Stdout, "\n"+strings.Repeat("─", 60))
+	fmt.Fprintln(w, "\n"+strings.Repeat("─", 60))
 
 	for i, item := range timeline {
 		if item.message != nil {
-			c.printMessage(*item.message)
+			c.printMessage(w, *item.message)
 		} else {
-			c.printComment(*item.comment)
+			c.printComment(w, *item.comment)
 		}
 
 		if i < len(timeline)-1 {
-			fmt.Fprintln(os.Stdout, strings.Repeat("─", 60))
+			fmt.Fprintln(w, strings.Repeat("─", 60))
 		}
 	}
-
-	return nil
 }
 
 func sortTimeline(items []timelineItem) {
@@ -368,7 +368,7 @@ func sortTimeline(items []timelineItem) {
 	}
 }
 
-func (c *ConvGetCmd) printMessage(msg api.Message) {
+func (c *ConvGetCmd) printMessage(w io.Writer, msg api.Message) {
 	// Direction
 	dir := "→"
 	if msg.IsInbound {
@@ -385,16 +385,16 @@ func (c *ConvGetCmd) printMessage(msg api.Message) {
 	}
 
 	// Header with message ID
-	fmt.Fprintf(os.Stdout, "%s %s  %s  [message:%s]\n", dir, from, output.FormatTimestamp(msg.CreatedAt), msg.ID)
-	fmt.Fprintln(os.Stdout)
+	fmt.Fprintf(w, "%s %s  %s  [message:%s]\n", dir, from, output.FormatTimestamp(msg.CreatedAt), msg.ID)
+	fmt.Fprintln(w)
 
 	// Body
 	body := c.formatMessageBody(msg)
-	fmt.Fprintln(os.Stdout, body)
-	fmt.Fprintln(os.Stdout)
+	fmt.Fprintln(w, body)
+	fmt.Fprintln(w)
 }
 
-func (c *ConvGetCmd) printComment(comment api.Comment) {
+func (c *ConvGetCmd) printComment(w io.Writer, comment api.Comment) {
 	// From
 	from := "-"
 	if comment.Author != nil {
@@ -405,12 +405,12 @@ func (c *ConvGetCmd) printComment(comment api.Comment) {
 	}
 
 	// Header with comment ID (# indicates internal comment)
-	fmt.Fprintf(os.Stdout, "# %s  %s  [comment:%s]\n", from, output.FormatTimestamp(comment.PostedAt), comment.ID)
-	fmt.Fprintln(os.Stdout)
+	fmt.Fprintf(w, "# %s  %s  [comment:%s]\n", from, output.FormatTimestamp(comment.PostedAt), comment.ID)
+	fmt.Fprintln(w)
 
 	// Body (comments are plain text)
-	fmt.Fprintln(os.Stdout, comment.Body)
-	fmt.Fprintln(os.Stdout)
+	fmt.Fprintln(w, comment.Body)
+	fmt.Fprintln(w)
 }
 
 func (c *ConvGetCmd) formatMessageBody(msg api.Message) string {
@@ -446,9 +446,19 @@ type ConvSearchCmd struct {
 	Before     string   `help:"Filter before date/time (before:)"`
 	After      string   `help:"Filter after date/time (after:)"`
 	Limit      int      `help:"Maximum results" default:"25"`
+	Offline    bool     `help:"Run a ranked full-text search over the local database from 'frontcli sync'"`
+
+	DownloadAttachments bool `help:"Download every attachment of the matching conversations instead of listing them" name:"download-attachments"`
+
+	PaginationFlags         `embed:""`
+	AttachmentDownloadFlags `embed:""`
 }
 
 func (c *ConvSearchCmd) Run(flags *RootFlags) error {
+	if c.Offline {
+		return c.runOffline(flags)
+	}
+
 	ctx := context.Background()
 
 	client, err := getClient(flags)
@@ -472,15 +482,32 @@ func (c *ConvSearchCmd) Run(flags *RootFlags) error {
 		params.Set("limit", fmt.Sprintf("%d", c.Limit))
 	}
 
-	var resp api.ListResponse[api.Conversation]
-	if err := client.Get(ctx, "/conversations/search?"+params.Encode(), &resp); err != nil {
+	listMode := mode
+	if c.DownloadAttachments {
+		// Collect the results instead of streaming them; the manifest is printed instead.
+		listMode.NDJSON = false
+	}
+
+	resp, err := fetchList[api.Conversation](ctx, client, "/conversations/search?"+params.Encode(), c.PaginationFlags, listMode)
+	if err != nil {
 		fmt.Fprint(os.Stderr, errfmt.Format(err))
 
 		return err
 	}
 
+	if c.DownloadAttachments {
+		ids := make([]string, len(resp.Results))
+		for i, conv := range resp.Results {
+			ids[i] = conv.ID
+		}
+
+		printMoreResultsHint(resp.Pagination.Next)
+
+		return downloadConversationAttachments(ctx, client, mode, ids, c.AttachmentDownloadFlags)
+	}
+
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, resp)
+		return output.Write(os.Stdout, mode, resp)
 	}
 
 	if len(resp.Results) == 0 {
@@ -489,19 +516,20 @@ func (c *ConvSearchCmd) Run(flags *RootFlags) error {
 		return nil
 	}
 
-	tbl := output.NewTableWriter(os.Stdout, mode.Plain)
-	tbl.AddRow("ID", "STATUS", "ASSIGNEE", "SUBJECT", "CREATED", "UPDATED")
-
-	for _, conv := range resp.Results {
-		tbl.AddRow(output.FormatConversationWithUpdated(conv)...)
+	if err := output.WriteRows(os.Stdout, mode, output.ConversationColumns, output.ConversationFieldsWithUpdated, resp.Results); err != nil {
+		return err
 	}
 
-	return tbl.Flush()
+	printMoreResultsHint(resp.Pagination.Next)
+
+	return nil
 }
 
 type ConvMessagesCmd struct {
 	ID    string `arg:"" help:"Conversation ID"`
 	Limit int    `help:"Maximum number of messages" default:"25"`
+
+	PaginationFlags `embed:""`
 }
 
 func (c *ConvMessagesCmd) Run(flags *RootFlags) error {
@@ -517,7 +545,9 @@ func (c *ConvMessagesCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
-	resp, err := client.ListConversationMessages(ctx, c.ID, c.Limit)
+	path := fmt.Sprintf("/conversations/%s/messages?limit=%d", c.ID, c.Limit)
+
+	resp, err := fetchList[api.Message](ctx, client, path, c.PaginationFlags, mode)
 	if err != nil {
 		fmt.Fprint(os.Stderr, errfmt.Format(err))
 
@@ -525,7 +555,7 @@ func (c *ConvMessagesCmd) Run(flags *RootFlags) error {
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, resp)
+		return output.Write(os.Stdout, mode, resp)
 	}
 
 	if len(resp.Results) == 0 {
@@ -534,19 +564,20 @@ func (c *ConvMessagesCmd) Run(flags *RootFlags) error {
 		return nil
 	}
 
-	tbl := output.NewTableWriter(os.Stdout, mode.Plain)
-	tbl.AddRow("ID", "DIR", "FROM", "PREVIEW", "DATE")
-
-	for _, msg := range resp.Results {
-		tbl.AddRow(output.FormatMessage(msg)...)
+	if err := output.WriteRows(os.Stdout, mode, output.MessageColumns, output.MessageFields, resp.Results); err != nil {
+		return err
 	}
 
-	return tbl.Flush()
+	printMoreResultsHint(resp.Pagination.Next)
+
+	return nil
 }
 
 type ConvCommentsCmd struct {
 	ID    string `arg:"" help:"Conversation ID"`
 	Limit int    `help:"Maximum number of comments" default:"25"`
+
+	PaginationFlags `embed:""`
 }
 
 func (c *ConvCommentsCmd) Run(flags *RootFlags) error {
@@ -562,15 +593,17 @@ func (c *ConvCommentsCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
-	var resp api.ListResponse[api.Comment]
-	if err := client.Get(ctx, fmt.Sprintf("/conversations/%s/comments?limit=%d", c.ID, c.Limit), &resp); err != nil {
+	path := fmt.Sprintf("/conversations/%s/comments?limit=%d", c.ID, c.Limit)
+
+	resp, err := fetchList[api.Comment](ctx, client, path, c.PaginationFlags, mode)
+	if err != nil {
 		fmt.Fprint(os.Stderr, errfmt.Format(err))
 
 		return err
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, resp)
+		return output.Write(os.Stdout, mode, resp)
 	}
 
 	if len(resp.Results) == 0 {
@@ -579,30 +612,11 @@ func (c *ConvCommentsCmd) Run(flags *RootFlags) error {
 		return nil
 	}
 
-	tbl := output.NewTableWriter(os.Stdout, mode.Plain)
-	tbl.AddRow("ID", "AUTHOR", "BODY", "DATE")
-
-	for _, comment := range resp.Results {
-		author := "-"
-		if comment.Author != nil {
-			author = comment.Author.Email
-			if author == "" {
-				author = comment.Author.Username
-			}
-		}
-
-		body := comment.Body
-		if len(body) > 50 {
-			body = body[:47] + "..."
-		}
-
-		tbl.AddRow(
-			comment.ID,
-			author,
-			body,
-			output.FormatTimestamp(comment.PostedAt),
-		)
+	if err := output.WriteRows(os.Stdout, mode, output.CommentColumns, output.CommentFields, resp.Results); err != nil {
+		return err
 	}
 
-	return tbl.Flush()
+	printMoreResultsHint(resp.Pagination.Next)
+
+	return nil
 }
@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+	"github.com/dedene/frontapp-cli/internal/output"
+	"github.com/dedene/frontapp-cli/internal/store"
+)
+
+// runOffline searches the local database instead of Front. Filters are
+// mapped onto the synced data; those that need Front's server-side state
+// are rejected.
+func (c *ConvSearchCmd) runOffline(flags *RootFlags) error {
+	ctx := context.Background()
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	opts, err := c.offlineOptions()
+	if err != nil {
+		return err
+	}
+
+	db, _, err := openAccountDB(ctx, flags, true)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	hits, err := db.Search(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	resp := api.ListResponse[api.Conversation]{Results: make([]api.Conversation, len(hits))}
+	for i, hit := range hits {
+		resp.Results[i] = hit.Conversation
+	}
+
+	if mode.JSON {
+		if mode.NDJSON {
+			return output.Write(os.Stdout, mode, resp.Results)
+		}
+
+		return output.Write(os.Stdout, mode, resp)
+	}
+
+	if len(resp.Results) == 0 {
+		fmt.Fprintln(os.Stdout, "No conversations found.")
+
+		return nil
+	}
+
+	return output.WriteRows(os.Stdout, mode, output.ConversationColumns, output.ConversationFieldsWithUpdated, resp.Results)
+}
+
+func (c *ConvSearchCmd) offlineOptions() (store.SearchOptions, error) {
+	unsupported := []struct {
+		name string
+		set  bool
+	}{
+		{"--recipient", c.Recipient != ""},
+		{"--inbox", c.Inbox != ""},
+		{"--status", c.Status != ""},
+		{"--assignee", c.Assignee != ""},
+		{"--unassigned", c.Unassigned},
+		{"--download-attachments", c.DownloadAttachments},
+		{"--page-token", c.PageToken != ""},
+	}
+
+	for _, f := range unsupported {
+		if f.set {
+			return store.SearchOptions{}, fmt.Errorf("%s is not supported with --offline", f.name)
+		}
+	}
+
+	query := c.Query
+	if strings.TrimSpace(c.RawQuery) != "" {
+		query = c.RawQuery
+	}
+
+	opts := store.SearchOptions{
+		Query: strings.TrimSpace(query),
+		From:  strings.TrimSpace(c.From),
+		To:    strings.TrimSpace(c.To),
+		Tags:  c.Tag,
+		Limit: c.Limit,
+	}
+
+	if c.All {
+		opts.Limit = 0
+	}
+
+	var err error
+
+	if opts.Before, err = parseSearchTime(c.Before); err != nil {
+		return opts, fmt.Errorf("invalid --before: %w", err)
+	}
+
+	if opts.After, err = parseSearchTime(c.After); err != nil {
+		return opts, fmt.Errorf("invalid --after: %w", err)
+	}
+
+	if opts.Query == "" && opts.From == "" && opts.To == "" && len(opts.Tags) == 0 && opts.Before == 0 && opts.After == 0 {
+		return opts, fmt.Errorf("no query provided")
+	}
+
+	return opts, nil
+}
+
+// parseSearchTime accepts the forms Front's before:/after: do (Unix
+// seconds) as well as RFC3339 times and YYYY-MM-DD dates in local time.
+func parseSearchTime(value string) (float64, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+
+	if secs, err := strconv.ParseFloat(value, 64); err == nil {
+		return secs, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return float64(t.Unix()), nil
+	}
+
+	t, err := time.ParseInLocation(time.DateOnly, value, time.Local)
+	if err != nil {
+		return 0, fmt.Errorf("expected Unix seconds, RFC3339 or YYYY-MM-DD: %q", value)
+	}
+
+	return float64(t.Unix()), nil
+}
@@ -73,7 +73,7 @@ func TestConvTagSendsTagIDs(t *testing.T) {
 	}
 	t.Cleanup(func() { newClientFromAuth = old })
 
-	cmd := ConvTagCmd{ID: "cnv_123", TagID: "tag_abc"}
+	cmd := ConvTagCmd{IDs: []string{"cnv_123"}, Tag: "tag_abc"}
 	flags := &RootFlags{Account: "test@example.com"}
 
 	if err := cmd.Run(flags); err != nil {
@@ -117,7 +117,7 @@ func TestConvArchiveIDsFromStdin(t *testing.T) {
 	os.Stdin = r
 	t.Cleanup(func() { os.Stdin = oldStdin })
 
-	cmd := ConvArchiveCmd{IDsFrom: "-"}
+	cmd := ConvArchiveCmd{BulkFlags: BulkFlags{IDsFrom: "-"}}
 	flags := &RootFlags{Account: "test@example.com"}
 
 	if err := cmd.Run(flags); err != nil {
@@ -0,0 +1,349 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+	"github.com/dedene/frontapp-cli/internal/errfmt"
+	"github.com/dedene/frontapp-cli/internal/output"
+)
+
+// watchBudgetShare is the fraction of the per-minute rate limit a watch may
+// spend on polling, leaving the rest for other tools sharing the token.
+const watchBudgetShare = 0.25
+
+// watchMemory caps how many conversations that left the listing a watch
+// keeps remembering, so one that comes back is reported as changed rather
+// than new without the state growing without bound.
+const watchMemory = 1000
+
+type ConvWatchCmd struct {
+	Inbox    string        `help:"Watch an inbox (ID or name)"`
+	Tag      string        `help:"Watch a tag (ID or name)"`
+	Status   string        `help:"Filter by status (open, assigned, unassigned, archived, snoozed, trashed)"`
+	Search   string        `help:"Watch a search query instead of a conversation listing"`
+	Limit    int           `help:"Conversations fetched per poll; only this many most recent are watched" default:"50"`
+	Interval time.Duration `help:"Minimum time between polls" default:"15s"`
+	Initial  bool          `help:"Also print the conversations that match when the watch starts"`
+}
+
+// watchEvent is printed for every new or changed conversation.
+type watchEvent struct {
+	Type         string           `json:"type"` // existing, new, changed, left
+	Changes      []string         `json:"changes,omitempty"`
+	ObservedAt   time.Time        `json:"observed_at"`
+	Conversation api.Conversation `json:"conversation"`
+}
+
+func (c *ConvWatchCmd) Run(flags *RootFlags) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	// Events are a stream, so structured output is always one event per line.
+	if mode.JSON {
+		mode.NDJSON = true
+		mode.YAML = false
+	}
+
+	path, err := c.path(ctx, newResolver(client, flags))
+	if err != nil {
+		return err
+	}
+
+	w := newConvWatcher()
+	w.lookup = func(id string) (*api.Conversation, error) { return client.GetConversation(ctx, id) }
+
+	for {
+		var resp api.ListResponse[api.Conversation]
+
+		err := client.Get(ctx, path, &resp)
+
+		switch {
+		case ctx.Err() != nil:
+			return nil
+		case err != nil:
+			var authErr *api.AuthError
+			if errors.As(err, &authErr) {
+				return err
+			}
+
+			fmt.Fprint(os.Stderr, errfmt.Format(err))
+		default:
+			if err := writeWatchEvents(mode, w.observe(resp.Results, c.Initial)); err != nil {
+				return err
+			}
+		}
+
+		wait := client.RateLimiter().PollInterval(c.Interval, watchBudgetShare)
+
+		var rateErr *api.RateLimitError
+		if errors.As(err, &rateErr) && rateErr.RetryAfter > 0 {
+			wait = max(wait, time.Duration(rateErr.RetryAfter)*time.Second)
+		}
+
+		if flags.Verbose {
+			fmt.Fprintf(os.Stderr, "Next poll in %s\n", wait)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(wait):
+		}
+	}
+}
+
+func (c *ConvWatchCmd) path(ctx context.Context, res *resolver) (string, error) {
+	if strings.TrimSpace(c.Search) != "" {
+		if c.Inbox != "" || c.Tag != "" || c.Status != "" {
+			return "", fmt.Errorf("--search cannot be combined with --inbox, --tag or --status")
+		}
+
+		params := url.Values{}
+		params.Set("q", c.Search)
+		params.Set("limit", fmt.Sprintf("%d", c.Limit))
+
+		return "/conversations/search?" + params.Encode(), nil
+	}
+
+	inboxID, err := res.inboxID(ctx, c.Inbox)
+	if err != nil {
+		return "", err
+	}
+
+	tagID, err := res.tagID(ctx, c.Tag)
+	if err != nil {
+		return "", err
+	}
+
+	opts := api.ListConversationsOptions{
+		InboxID:  inboxID,
+		TagID:    tagID,
+		Statuses: api.ParseStatus(c.Status),
+		Limit:    c.Limit,
+	}
+
+	return "/conversations?" + opts.Query(), nil
+}
+
+// convSnapshot holds the fields whose change triggers an event.
+type convSnapshot struct {
+	status       string
+	assignee     string
+	tags         string
+	waitingSince float64
+}
+
+func snapshotOf(conv api.Conversation) convSnapshot {
+	snap := convSnapshot{status: conv.Status, waitingSince: conv.WaitingSince}
+
+	if conv.Assignee != nil {
+		snap.assignee = conv.Assignee.ID
+	}
+
+	tags := make([]string, len(conv.Tags))
+	for i, t := range conv.Tags {
+		tags[i] = t.ID
+	}
+
+	slices.Sort(tags)
+	snap.tags = strings.Join(tags, ",")
+
+	return snap
+}
+
+func (s convSnapshot) diff(other convSnapshot) []string {
+	var changes []string
+
+	if s.status != other.status {
+		changes = append(changes, "status")
+	}
+
+	if s.assignee != other.assignee {
+		changes = append(changes, "assignee")
+	}
+
+	if s.tags != other.tags {
+		changes = append(changes, "tags")
+	}
+
+	if s.waitingSince != other.waitingSince {
+		changes = append(changes, "waiting_since")
+	}
+
+	return changes
+}
+
+// watchEntry is what a watch remembers about one conversation.
+type watchEntry struct {
+	conv    api.Conversation
+	snap    convSnapshot
+	present bool // in the listing at the last poll
+	leftAt  int  // poll at which it left the listing
+}
+
+// convWatcher remembers what each conversation looked like at the last poll.
+type convWatcher struct {
+	seen   map[string]*watchEntry
+	polls  int
+	primed bool
+	now    func() time.Time
+	// lookup fetches the current state of a conversation that left the
+	// listing; if nil or failing, the last known state is reported.
+	lookup func(id string) (*api.Conversation, error)
+}
+
+func newConvWatcher() *convWatcher {
+	return &convWatcher{seen: make(map[string]*watchEntry), now: time.Now}
+}
+
+// observe records a poll result and returns events for conversations that
+// are new, changed or left the listing since the previous poll. The first
+// poll only builds the baseline unless initial is set.
+func (w *convWatcher) observe(convs []api.Conversation, initial bool) []watchEvent {
+	var events []watchEvent
+
+	w.polls++
+	now := w.now().UTC()
+	current := make(map[string]struct{}, len(convs))
+
+	for _, conv := range convs {
+		current[conv.ID] = struct{}{}
+
+		snap := snapshotOf(conv)
+		prev, known := w.seen[conv.ID]
+		w.seen[conv.ID] = &watchEntry{conv: conv, snap: snap, present: true}
+
+		switch {
+		case !w.primed:
+			if initial {
+				events = append(events, watchEvent{Type: "existing", ObservedAt: now, Conversation: conv})
+			}
+		case !known:
+			events = append(events, watchEvent{Type: "new", ObservedAt: now, Conversation: conv})
+		case !prev.present:
+			// Back in the listing: report it even if nothing we track changed.
+			events = append(events, watchEvent{Type: "changed", Changes: prev.snap.diff(snap), ObservedAt: now, Conversation: conv})
+		default:
+			if changes := prev.snap.diff(snap); len(changes) > 0 {
+				events = append(events, watchEvent{Type: "changed", Changes: changes, ObservedAt: now, Conversation: conv})
+			}
+		}
+	}
+
+	var left []string
+
+	for id, entry := range w.seen {
+		if _, ok := current[id]; !ok && entry.present {
+			left = append(left, id)
+		}
+	}
+
+	slices.Sort(left)
+
+	for _, id := range left {
+		entry := w.seen[id]
+		conv := entry.conv
+
+		if w.lookup != nil {
+			if fresh, err := w.lookup(id); err == nil {
+				conv = *fresh
+			}
+		}
+
+		snap := snapshotOf(conv)
+		events = append(events, watchEvent{Type: "left", Changes: entry.snap.diff(snap), ObservedAt: now, Conversation: conv})
+		w.seen[id] = &watchEntry{conv: conv, snap: snap, leftAt: w.polls}
+	}
+
+	w.forget()
+	w.primed = true
+
+	return events
+}
+
+// forget drops the conversations that left the listing longest ago once
+// more than watchMemory of them are remembered.
+func (w *convWatcher) forget() {
+	var gone []string
+
+	for id, entry := range w.seen {
+		if !entry.present {
+			gone = append(gone, id)
+		}
+	}
+
+	if len(gone) <= watchMemory {
+		return
+	}
+
+	slices.SortFunc(gone, func(a, b string) int { return w.seen[a].leftAt - w.seen[b].leftAt })
+
+	for _, id := range gone[:len(gone)-watchMemory] {
+		delete(w.seen, id)
+	}
+}
+
+// watchColumns are the event columns followed by every conversation column,
+// so --fields can mix both.
+var watchColumns = func() output.Columns[watchEvent] {
+	cols := output.Columns[watchEvent]{
+		{Name: "observed", Header: "OBSERVED", Value: func(ev watchEvent) string {
+			return output.FormatTimestamp(float64(ev.ObservedAt.Unix()))
+		}},
+		{Name: "event", Header: "EVENT", Value: func(ev watchEvent) string { return strings.ToUpper(ev.Type) }},
+		{Name: "changes", Header: "CHANGES", Value: func(ev watchEvent) string { return strings.Join(ev.Changes, ",") }},
+	}
+
+	for _, col := range output.ConversationColumns {
+		cols = append(cols, output.Column[watchEvent]{
+			Name:   col.Name,
+			Header: col.Header,
+			Value:  func(ev watchEvent) string { return col.Value(ev.Conversation) },
+			Width:  col.Width,
+		})
+	}
+
+	return cols
+}()
+
+var watchFields = slices.Concat([]string{"observed", "event"}, output.ConversationFields, []string{"changes"})
+
+// writeWatchEvents prints the events of one poll: one JSON line each, or a
+// table with a header per poll.
+func writeWatchEvents(mode output.Mode, events []watchEvent) error {
+	if mode.JSON {
+		for _, ev := range events {
+			if err := output.Write(os.Stdout, mode, ev); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	return output.WriteRows(os.Stdout, mode, watchColumns, watchFields, events)
+}
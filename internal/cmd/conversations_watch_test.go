@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+)
+
+func TestConvWatcherReportsNewAndChanged(t *testing.T) {
+	w := newConvWatcher()
+
+	first := []api.Conversation{
+		{ID: "cnv_1", Status: "unassigned"},
+		{ID: "cnv_2", Status: "unassigned", Tags: []api.Tag{{ID: "tag_b"}, {ID: "tag_a"}}},
+	}
+
+	if events := w.observe(first, false); len(events) != 0 {
+		t.Fatalf("expected no events on the first poll, got %+v", events)
+	}
+
+	second := []api.Conversation{
+		{ID: "cnv_1", Status: "assigned", Assignee: &api.Teammate{ID: "tea_1"}},
+		{ID: "cnv_2", Status: "unassigned", Tags: []api.Tag{{ID: "tag_a"}, {ID: "tag_b"}}},
+		{ID: "cnv_3", Status: "unassigned"},
+	}
+
+	events := w.observe(second, false)
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %+v", events)
+	}
+
+	if events[0].Type != "changed" || events[0].Conversation.ID != "cnv_1" ||
+		len(events[0].Changes) != 2 || events[0].Changes[0] != "status" || events[0].Changes[1] != "assignee" {
+		t.Fatalf("unexpected change event: %+v", events[0])
+	}
+
+	if events[1].Type != "new" || events[1].Conversation.ID != "cnv_3" {
+		t.Fatalf("unexpected new event: %+v", events[1])
+	}
+
+}
+
+func TestConvWatcherReportsLeftAndReturning(t *testing.T) {
+	w := newConvWatcher()
+	w.lookup = func(id string) (*api.Conversation, error) {
+		return &api.Conversation{ID: id, Status: "assigned", Assignee: &api.Teammate{ID: "tea_1"}}, nil
+	}
+
+	both := []api.Conversation{{ID: "cnv_1", Status: "unassigned"}, {ID: "cnv_2", Status: "unassigned"}}
+	w.observe(both, false)
+
+	// cnv_1 gets assigned and leaves an --status unassigned listing.
+	events := w.observe(both[1:], false)
+	if len(events) != 1 || events[0].Type != "left" || events[0].Conversation.ID != "cnv_1" ||
+		events[0].Conversation.Status != "assigned" || strings.Join(events[0].Changes, ",") != "status,assignee" {
+		t.Fatalf("unexpected left event: %+v", events)
+	}
+
+	// It comes back unassigned: a change, not a new conversation.
+	events = w.observe(both, false)
+	if len(events) != 1 || events[0].Type != "changed" || events[0].Conversation.ID != "cnv_1" ||
+		strings.Join(events[0].Changes, ",") != "status,assignee" {
+		t.Fatalf("unexpected return event: %+v", events)
+	}
+}
+
+func TestConvWatcherForgetsOldestLeft(t *testing.T) {
+	w := newConvWatcher()
+
+	convs := make([]api.Conversation, watchMemory+2)
+	for i := range convs {
+		convs[i] = api.Conversation{ID: fmt.Sprintf("cnv_%d", i)}
+	}
+
+	w.observe(convs, false)
+	w.observe(convs[1:], false) // cnv_0 leaves first
+	w.observe(nil, false)
+
+	if len(w.seen) != watchMemory {
+		t.Fatalf("expected %d remembered conversations, got %d", watchMemory, len(w.seen))
+	}
+
+	if _, ok := w.seen["cnv_0"]; ok {
+		t.Fatal("expected the conversation that left first to be forgotten")
+	}
+}
+
+func TestWatchColumnsMixEventAndConversationFields(t *testing.T) {
+	ev := watchEvent{Type: "changed", Changes: []string{"status"}, Conversation: api.Conversation{ID: "cnv_1", Status: "assigned"}}
+
+	cols, err := watchColumns.Select([]string{"event", "id", "status", "changes"})
+	if err != nil {
+		t.Fatalf("Select: %v", err)
+	}
+
+	var got []string
+	for _, col := range cols {
+		got = append(got, col.Value(ev))
+	}
+
+	if strings.Join(got, " ") != "CHANGED cnv_1 assigned status" {
+		t.Fatalf("unexpected row: %v", got)
+	}
+}
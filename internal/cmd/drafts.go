@@ -3,6 +3,7 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
 
 	"github.com/dedene/frontapp-cli/internal/api"
@@ -20,11 +21,15 @@ type DraftCmd struct {
 
 type DraftCreateCmd struct {
 	ConvID   string `arg:"" help:"Conversation ID (for reply drafts)" optional:""`
-	Channel  string `help:"Channel ID (for new message drafts)"`
+	Channel  string `help:"Channel for new message drafts (ID, name or address)"`
 	To       string `help:"Recipient (for new message drafts)"`
 	Subject  string `help:"Draft subject"`
 	Body     string `help:"Draft body"`
 	BodyFile string `help:"Read body from file" type:"existingfile"`
+	Edit     bool   `help:"Compose in $EDITOR with To/Cc/Bcc/Subject/Channel headers"`
+
+	BodyFormatFlags `embed:""`
+	AttachFlags     `embed:""`
 }
 
 func (c *DraftCreateCmd) Run(flags *RootFlags) error {
@@ -40,6 +45,11 @@ func (c *DraftCreateCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
+	files, err := api.LoadAttachments(c.Attach)
+	if err != nil {
+		return err
+	}
+
 	body := c.Body
 	if c.BodyFile != "" {
 		data, err := os.ReadFile(c.BodyFile)
@@ -50,37 +60,70 @@ func (c *DraftCreateCmd) Run(flags *RootFlags) error {
 		body = string(data)
 	}
 
-	req := map[string]any{
-		"body": body,
+	msg := composition{Subject: c.Subject, Channel: c.Channel, Body: body}
+	if c.To != "" {
+		msg.To = []string{c.To}
 	}
 
-	if c.Subject != "" {
-		req["subject"] = c.Subject
+	if c.Edit {
+		headers, hint, quote := messageHeaders, "", ""
+
+		if c.ConvID != "" {
+			headers, hint = replyHeaders, replyHint
+
+			quote, err = replyQuote(ctx, client, c.ConvID, "")
+			if err != nil {
+				fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+				return err
+			}
+		}
+
+		msg, err = editComposition(msg, headers, hint, quote)
+		if err != nil {
+			return err
+		}
 	}
 
-	if c.To != "" {
-		req["to"] = []string{c.To}
+	msg.Body, err = c.render(msg.Body, c.Edit)
+	if err != nil {
+		return err
+	}
+
+	req := map[string]any{
+		"body": msg.Body,
 	}
 
+	if len(msg.To) > 0 {
+		req["to"] = msg.To
+	}
+
+	addComposedFields(req, msg)
+
 	var path string
 	switch {
 	case c.ConvID != "":
 		path = fmt.Sprintf("/conversations/%s/drafts", c.ConvID)
-	case c.Channel != "":
-		path = fmt.Sprintf("/channels/%s/drafts", c.Channel)
+	case msg.Channel != "":
+		channelID, err := newResolver(client, flags).channelID(ctx, msg.Channel)
+		if err != nil {
+			return err
+		}
+
+		path = fmt.Sprintf("/channels/%s/drafts", channelID)
 	default:
 		return fmt.Errorf("either conversation ID or --channel is required")
 	}
 
 	var result api.Draft
-	if err := client.Post(ctx, path, req, &result); err != nil {
+	if err := sendBody(ctx, client, http.MethodPost, path, req, files, &result); err != nil {
 		fmt.Fprint(os.Stderr, errfmt.Format(err))
 
 		return err
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, result)
+		return output.Write(os.Stdout, mode, result)
 	}
 
 	fmt.Fprintf(os.Stdout, "Draft created: %s\n", result.ID)
@@ -113,7 +156,7 @@ func (c *DraftListCmd) Run(flags *RootFlags) error {
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, resp)
+		return output.Write(os.Stdout, mode, resp)
 	}
 
 	if len(resp.Results) == 0 {
@@ -122,19 +165,7 @@ func (c *DraftListCmd) Run(flags *RootFlags) error {
 		return nil
 	}
 
-	tbl := output.NewTableWriter(os.Stdout, mode.Plain)
-	tbl.AddRow("ID", "VERSION", "SUBJECT", "CREATED")
-
-	for _, draft := range resp.Results {
-		tbl.AddRow(
-			draft.ID,
-			fmt.Sprintf("%d", draft.Version),
-			draft.Subject,
-			output.FormatTimestamp(draft.CreatedAt),
-		)
-	}
-
-	return tbl.Flush()
+	return output.WriteRows(os.Stdout, mode, output.DraftColumns, output.DraftFields, resp.Results)
 }
 
 type DraftGetCmd struct {
@@ -162,7 +193,11 @@ func (c *DraftGetCmd) Run(flags *RootFlags) error {
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, draft)
+		return output.Write(os.Stdout, mode, draft)
+	}
+
+	if mode.Columnar() {
+		return output.WriteItem(os.Stdout, mode, output.DraftColumns, draft)
 	}
 
 	fmt.Fprintf(os.Stdout, "ID:      %s\n", draft.ID)
@@ -185,6 +220,9 @@ type DraftUpdateCmd struct {
 	BodyFile     string `help:"Read body from file" type:"existingfile"`
 	Subject      string `help:"New subject"`
 	DraftVersion int    `required:"" name:"draft-version" help:"Current version number (for optimistic locking)"`
+
+	BodyFormatFlags `embed:""`
+	AttachFlags     `embed:""`
 }
 
 func (c *DraftUpdateCmd) Run(flags *RootFlags) error {
@@ -200,6 +238,11 @@ func (c *DraftUpdateCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
+	files, err := api.LoadAttachments(c.Attach)
+	if err != nil {
+		return err
+	}
+
 	body := c.Body
 	if c.BodyFile != "" {
 		data, err := os.ReadFile(c.BodyFile)
@@ -215,7 +258,10 @@ func (c *DraftUpdateCmd) Run(flags *RootFlags) error {
 	}
 
 	if body != "" {
-		req["body"] = body
+		req["body"], err = c.render(body, false)
+		if err != nil {
+			return err
+		}
 	}
 
 	if c.Subject != "" {
@@ -223,14 +269,14 @@ func (c *DraftUpdateCmd) Run(flags *RootFlags) error {
 	}
 
 	var result api.Draft
-	if err := client.Patch(ctx, "/drafts/"+c.ID, req, &result); err != nil {
+	if err := sendBody(ctx, client, http.MethodPatch, "/drafts/"+c.ID, req, files, &result); err != nil {
 		fmt.Fprint(os.Stderr, errfmt.Format(err))
 
 		return err
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, result)
+		return output.Write(os.Stdout, mode, result)
 	}
 
 	fmt.Fprintf(os.Stdout, "Draft updated (new version: %d)\n", result.Version)
@@ -40,7 +40,7 @@ func (c *InboxListCmd) Run(flags *RootFlags) error {
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, resp)
+		return output.Write(os.Stdout, mode, resp)
 	}
 
 	if len(resp.Results) == 0 {
@@ -49,14 +49,7 @@ func (c *InboxListCmd) Run(flags *RootFlags) error {
 		return nil
 	}
 
-	tbl := output.NewTableWriter(os.Stdout, mode.Plain)
-	tbl.AddRow("ID", "NAME")
-
-	for _, inbox := range resp.Results {
-		tbl.AddRow(output.FormatInbox(inbox)...)
-	}
-
-	return tbl.Flush()
+	return output.WriteRows(os.Stdout, mode, output.InboxColumns, output.InboxFields, resp.Results)
 }
 
 type InboxGetCmd struct {
@@ -84,7 +77,11 @@ func (c *InboxGetCmd) Run(flags *RootFlags) error {
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, inbox)
+		return output.Write(os.Stdout, mode, inbox)
+	}
+
+	if mode.Columnar() {
+		return output.WriteItem(os.Stdout, mode, output.InboxColumns, *inbox)
 	}
 
 	fmt.Fprintf(os.Stdout, "ID:      %s\n", inbox.ID)
@@ -97,6 +94,8 @@ func (c *InboxGetCmd) Run(flags *RootFlags) error {
 type InboxConvosCmd struct {
 	ID    string `arg:"" help:"Inbox ID"`
 	Limit int    `help:"Maximum number of results" default:"25"`
+
+	PaginationFlags `embed:""`
 }
 
 func (c *InboxConvosCmd) Run(flags *RootFlags) error {
@@ -113,15 +112,15 @@ func (c *InboxConvosCmd) Run(flags *RootFlags) error {
 	}
 
 	path := fmt.Sprintf("/inboxes/%s/conversations?limit=%d", c.ID, c.Limit)
-	var resp api.ListResponse[api.Conversation]
-	if err := client.Get(ctx, path, &resp); err != nil {
+	resp, err := fetchList[api.Conversation](ctx, client, path, c.PaginationFlags, mode)
+	if err != nil {
 		fmt.Fprint(os.Stderr, errfmt.Format(err))
 
 		return err
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, resp)
+		return output.Write(os.Stdout, mode, resp)
 	}
 
 	if len(resp.Results) == 0 {
@@ -130,14 +129,13 @@ func (c *InboxConvosCmd) Run(flags *RootFlags) error {
 		return nil
 	}
 
-	tbl := output.NewTableWriter(os.Stdout, mode.Plain)
-	tbl.AddRow("ID", "STATUS", "ASSIGNEE", "SUBJECT", "CREATED")
-
-	for _, conv := range resp.Results {
-		tbl.AddRow(output.FormatConversation(conv)...)
+	if err := output.WriteRows(os.Stdout, mode, output.ConversationColumns, output.ConversationFields, resp.Results); err != nil {
+		return err
 	}
 
-	return tbl.Flush()
+	printMoreResultsHint(resp.Pagination.Next)
+
+	return nil
 }
 
 type InboxChannelsCmd struct {
@@ -165,7 +163,7 @@ func (c *InboxChannelsCmd) Run(flags *RootFlags) error {
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, resp)
+		return output.Write(os.Stdout, mode, resp)
 	}
 
 	if len(resp.Results) == 0 {
@@ -174,12 +172,5 @@ func (c *InboxChannelsCmd) Run(flags *RootFlags) error {
 		return nil
 	}
 
-	tbl := output.NewTableWriter(os.Stdout, mode.Plain)
-	tbl.AddRow("ID", "TYPE", "NAME", "ADDRESS")
-
-	for _, ch := range resp.Results {
-		tbl.AddRow(output.FormatChannel(ch)...)
-	}
-
-	return tbl.Flush()
+	return output.WriteRows(os.Stdout, mode, output.ChannelColumns, output.ChannelFields, resp.Results)
 }
@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+	"github.com/dedene/frontapp-cli/internal/output"
+)
+
+type McpCmd struct {
+	Serve McpServeCmd `cmd:"" help:"Serve Front tools and resources to an MCP client over stdio"`
+}
+
+// Destructive tool groups that must be enabled with --allow.
+const (
+	mcpAllowSend   = "send"
+	mcpAllowTrash  = "trash"
+	mcpAllowDelete = "delete"
+)
+
+type McpServeCmd struct {
+	Allow []string `help:"Enable destructive tools: send (send and reply), trash (trash conversations), delete (delete drafts and contacts)" enum:"send,trash,delete" sep:","`
+}
+
+func (c *McpServeCmd) Run(flags *RootFlags) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	server := newMCPServer(client, flags, c.Allow)
+
+	if err := server.Run(ctx, &mcp.StdioTransport{}); err != nil && ctx.Err() == nil {
+		return fmt.Errorf("mcp server: %w", err)
+	}
+
+	return nil
+}
+
+const mcpInstructions = `Tools and resources for the Front shared inbox of the account frontcli is logged in to.
+Inboxes, tags, teammates and channels can be referenced by ID or by name.
+List tools return next_page_token when more results exist; pass it back as page_token.
+Message bodies are returned as Markdown in body_markdown.`
+
+// newMCPServer builds an MCP server exposing client. Tools that send email,
+// trash conversations or delete resources are only registered when their
+// group is listed in allow.
+func newMCPServer(client *api.Client, flags *RootFlags, allow []string) *mcp.Server {
+	server := mcp.NewServer(
+		&mcp.Implementation{Name: "frontcli", Title: "Front", Version: VersionString()},
+		&mcp.ServerOptions{Instructions: mcpInstructions},
+	)
+
+	t := &mcpTools{client: client, resolver: newResolver(client, flags)}
+
+	t.addReadTools(server)
+	t.addWriteTools(server)
+
+	for _, group := range allow {
+		switch group {
+		case mcpAllowSend:
+			t.addSendTools(server)
+		case mcpAllowTrash:
+			t.addTrashTools(server)
+		case mcpAllowDelete:
+			t.addDeleteTools(server)
+		}
+	}
+
+	t.addResources(server)
+
+	return server
+}
+
+// mcpPage is one page of a list tool's results.
+type mcpPage[T any] struct {
+	Results       []T    `json:"results"`
+	NextPageToken string `json:"next_page_token,omitempty"`
+}
+
+// listPage fetches one page of a list endpoint, starting at pageToken.
+func listPage[T any](ctx context.Context, client *api.Client, path, pageToken string) (*mcpPage[T], error) {
+	resp, err := fetchList[T](ctx, client, path, PaginationFlags{PageToken: pageToken}, output.Mode{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &mcpPage[T]{Results: resp.Results, NextPageToken: api.PageToken(resp.Pagination.Next)}, nil
+}
+
+type mcpToolKind int
+
+const (
+	mcpRead mcpToolKind = iota
+	mcpWrite
+	mcpDestructive
+)
+
+// addMCPTool registers a tool whose handler returns a JSON-serializable
+// value. Errors are reported to the client as tool errors.
+func addMCPTool[In any](server *mcp.Server, kind mcpToolKind, name, description string, handler func(context.Context, In) (any, error)) {
+	readOnly := kind == mcpRead
+	destructive := kind == mcpDestructive
+
+	tool := &mcp.Tool{
+		Name:        name,
+		Description: description,
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:    readOnly,
+			DestructiveHint: &destructive,
+		},
+	}
+
+	mcp.AddTool(server, tool, func(ctx context.Context, _ *mcp.CallToolRequest, in In) (*mcp.CallToolResult, any, error) {
+		out, err := handler(ctx, in)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return nil, out, nil
+	})
+}
+
+// addResources registers read-only resources addressed by front:// URIs.
+func (t *mcpTools) addResources(server *mcp.Server) {
+	for _, r := range []struct{ uri, name, description string }{
+		{"front://tags", "tags", "All tags"},
+		{"front://inboxes", "inboxes", "All inboxes"},
+		{"front://channels", "channels", "All channels"},
+	} {
+		server.AddResource(&mcp.Resource{URI: r.uri, Name: r.name, Description: r.description, MIMEType: "application/json"}, t.readResource)
+	}
+
+	for _, r := range []struct{ uri, name, description string }{
+		{"front://conversations/{id}", "conversation", "A conversation"},
+		{"front://conversations/{id}/messages", "conversation-messages", "The latest messages of a conversation"},
+		{"front://messages/{id}", "message", "A message with its body as Markdown"},
+		{"front://contacts/{id}", "contact", "A contact"},
+	} {
+		server.AddResourceTemplate(&mcp.ResourceTemplate{URITemplate: r.uri, Name: r.name, Description: r.description, MIMEType: "application/json"}, t.readResource)
+	}
+}
+
+func (t *mcpTools) readResource(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	uri := req.Params.URI
+	parts := strings.Split(strings.TrimPrefix(uri, "front://"), "/")
+
+	var (
+		v   any
+		err error
+	)
+
+	switch {
+	case len(parts) == 1 && parts[0] == "tags":
+		v, err = t.client.ListTags(ctx)
+	case len(parts) == 1 && parts[0] == "inboxes":
+		v, err = t.client.ListInboxes(ctx)
+	case len(parts) == 1 && parts[0] == "channels":
+		v, err = t.client.ListChannels(ctx)
+	case len(parts) == 2 && parts[0] == "conversations":
+		v, err = t.conversation(ctx, mcpConversationInput{ConversationID: parts[1]})
+	case len(parts) == 3 && parts[0] == "conversations" && parts[2] == "messages":
+		v, err = t.messages(ctx, mcpMessagesInput{ConversationID: parts[1]})
+	case len(parts) == 2 && parts[0] == "messages":
+		v, err = t.message(ctx, mcpMessageInput{MessageID: parts[1]})
+	case len(parts) == 2 && parts[0] == "contacts":
+		v, err = t.contact(ctx, mcpContactInput{ContactID: parts[1]})
+	default:
+		return nil, mcp.ResourceNotFoundError(uri)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encode %s: %w", uri, err)
+	}
+
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{{URI: uri, MIMEType: "application/json", Text: string(data)}},
+	}, nil
+}
@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/oauth2"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+)
+
+func connectMCP(t *testing.T, srv *httptest.Server, allow []string) *mcp.ClientSession {
+	t.Helper()
+
+	ctx := context.Background()
+	client := api.NewClientWithBaseURL(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}), srv.URL)
+	server := newMCPServer(client, &RootFlags{}, allow)
+
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	if _, err := server.Connect(ctx, serverTransport, nil); err != nil {
+		t.Fatalf("server connect: %v", err)
+	}
+
+	session, err := mcp.NewClient(&mcp.Implementation{Name: "test", Version: "v0"}, nil).Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("client connect: %v", err)
+	}
+	t.Cleanup(func() { _ = session.Close() })
+
+	return session
+}
+
+func mcpToolNames(t *testing.T, session *mcp.ClientSession) []string {
+	t.Helper()
+
+	var names []string
+
+	for tool, err := range session.Tools(context.Background(), nil) {
+		if err != nil {
+			t.Fatalf("list tools: %v", err)
+		}
+
+		names = append(names, tool.Name)
+	}
+
+	return names
+}
+
+func TestMCPDestructiveToolsNeedAllow(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	names := mcpToolNames(t, connectMCP(t, srv, nil))
+	for _, name := range []string{"send_message", "reply_to_conversation", "trash_conversation", "delete_draft", "delete_contact"} {
+		if slices.Contains(names, name) {
+			t.Errorf("%s registered without --allow", name)
+		}
+	}
+
+	if !slices.Contains(names, "create_draft") || !slices.Contains(names, "get_conversation") {
+		t.Fatalf("missing default tools: %v", names)
+	}
+
+	names = mcpToolNames(t, connectMCP(t, srv, []string{mcpAllowSend}))
+	if !slices.Contains(names, "send_message") || !slices.Contains(names, "reply_to_conversation") {
+		t.Errorf("send tools missing with --allow send: %v", names)
+	}
+
+	if slices.Contains(names, "trash_conversation") {
+		t.Errorf("trash_conversation registered with --allow send only")
+	}
+}
+
+func TestMCPListMessages(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/conversations/cnv_1/messages" || r.URL.Query().Get("limit") != "25" {
+			t.Errorf("unexpected request %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		_, _ = io.WriteString(w, `{"_results":[{"id":"msg_1","body":"<p>Hello <b>there</b></p>"}],
+			"_pagination":{"next":"https://api2.frontapp.com/conversations/cnv_1/messages?page_token=abc"}}`)
+	}))
+	defer srv.Close()
+
+	session := connectMCP(t, srv, nil)
+
+	res, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+		Name:      "list_messages",
+		Arguments: map[string]any{"conversation_id": "cnv_1"},
+	})
+	if err != nil {
+		t.Fatalf("CallTool: %v", err)
+	}
+
+	if res.IsError {
+		t.Fatalf("tool error: %v", res.Content)
+	}
+
+	data, err := json.Marshal(res.StructuredContent)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var page mcpPage[mcpMessage]
+	if err := json.Unmarshal(data, &page); err != nil {
+		t.Fatalf("decode %s: %v", data, err)
+	}
+
+	if page.NextPageToken != "abc" || len(page.Results) != 1 {
+		t.Fatalf("unexpected page: %s", data)
+	}
+
+	if got := page.Results[0].BodyMarkdown; !strings.Contains(got, "Hello **there**") || page.Results[0].Body != "" {
+		t.Fatalf("expected Markdown body only, got %s", data)
+	}
+}
+
+func TestMCPToolErrorsAreReported(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = io.WriteString(w, `{"_error":{"status":404,"title":"Not found","message":"Resource not found"}}`)
+	}))
+	defer srv.Close()
+
+	session := connectMCP(t, srv, nil)
+
+	res, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+		Name:      "get_conversation",
+		Arguments: map[string]any{"conversation_id": "cnv_missing"},
+	})
+	if err != nil {
+		t.Fatalf("CallTool: %v", err)
+	}
+
+	if !res.IsError {
+		t.Fatal("expected a tool error")
+	}
+}
+
+func TestMCPRejectsTraversalIDs(t *testing.T) {
+	var requests []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.String())
+		_, _ = io.WriteString(w, `{}`)
+	}))
+	defer srv.Close()
+
+	session := connectMCP(t, srv, []string{mcpAllowTrash})
+
+	for _, id := range []string{"../../contacts/crd_x#", "cnv_1/../../contacts/crd_x", "msg_1"} {
+		res, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+			Name:      "trash_conversation",
+			Arguments: map[string]any{"conversation_id": id},
+		})
+		if err != nil {
+			t.Fatalf("CallTool: %v", err)
+		}
+
+		if !res.IsError {
+			t.Errorf("expected %q to be rejected", id)
+		}
+	}
+
+	if _, err := session.ReadResource(context.Background(), &mcp.ReadResourceParams{URI: "front://contacts/crd_x#"}); err == nil {
+		t.Error("expected the resource read to fail")
+	}
+
+	if len(requests) != 0 {
+		t.Fatalf("unexpected API requests: %v", requests)
+	}
+}
@@ -0,0 +1,543 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+	"github.com/dedene/frontapp-cli/internal/markdown"
+)
+
+// mcpTools implements the MCP tools on top of the API client.
+type mcpTools struct {
+	client   *api.Client
+	resolver *resolver
+}
+
+const mcpDefaultLimit = 25
+
+// mcpLimit applies the default page size and Front's maximum of 100.
+func mcpLimit(limit int) int {
+	if limit <= 0 {
+		return mcpDefaultLimit
+	}
+
+	return min(limit, 100)
+}
+
+// mcpPathID checks that id is a Front ID with one of prefixes and escapes
+// it for a URL path. IDs come from the model, so anything else, such as
+// "../../contacts/crd_x#", is rejected instead of being spliced into the
+// request path.
+func mcpPathID(id string, prefixes ...string) (string, error) {
+	prefix := api.ExtractPrefix(id)
+	if !slices.Contains(prefixes, prefix) {
+		if err := api.ValidateIDPrefix(id, prefixes[0]); err != nil {
+			return "", err
+		}
+
+		return "", fmt.Errorf("invalid %s ID %q (expected %s...)", api.ResourcePrefixes[prefixes[0]], id, strings.Join(prefixes, "... or "))
+	}
+
+	rest := id[len(prefix):]
+	if rest == "" || strings.IndexFunc(rest, func(r rune) bool {
+		return (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9')
+	}) >= 0 {
+		return "", fmt.Errorf("invalid %s ID %q", api.ResourcePrefixes[prefix], id)
+	}
+
+	return url.PathEscape(id), nil
+}
+
+type mcpListConversationsInput struct {
+	Inbox     string `json:"inbox,omitempty" jsonschema:"Only conversations in this inbox (ID or name)"`
+	Tag       string `json:"tag,omitempty" jsonschema:"Only conversations with this tag (ID or name)"`
+	Status    string `json:"status,omitempty" jsonschema:"open, archived, assigned, unassigned, snoozed or trashed"`
+	Limit     int    `json:"limit,omitempty" jsonschema:"Page size (default 25, max 100)"`
+	PageToken string `json:"page_token,omitempty" jsonschema:"next_page_token from a previous call"`
+}
+
+type mcpSearchInput struct {
+	Query     string `json:"query" jsonschema:"Front search query, e.g. 'invoice tag:billing is:open from:jane@example.com'"`
+	Limit     int    `json:"limit,omitempty" jsonschema:"Page size (default 25, max 100)"`
+	PageToken string `json:"page_token,omitempty" jsonschema:"next_page_token from a previous call"`
+}
+
+type mcpConversationInput struct {
+	ConversationID string `json:"conversation_id" jsonschema:"Conversation ID (cnv_...)"`
+}
+
+type mcpMessagesInput struct {
+	ConversationID string `json:"conversation_id" jsonschema:"Conversation ID (cnv_...)"`
+	Limit          int    `json:"limit,omitempty" jsonschema:"Page size (default 25, max 100)"`
+	PageToken      string `json:"page_token,omitempty" jsonschema:"next_page_token from a previous call"`
+}
+
+type mcpMessageInput struct {
+	MessageID string `json:"message_id" jsonschema:"Message ID (msg_...)"`
+}
+
+type mcpCommentsInput struct {
+	ConversationID string `json:"conversation_id" jsonschema:"Conversation ID (cnv_...)"`
+	PageToken      string `json:"page_token,omitempty" jsonschema:"next_page_token from a previous call"`
+}
+
+type mcpListContactsInput struct {
+	Limit     int    `json:"limit,omitempty" jsonschema:"Page size (default 25, max 100)"`
+	PageToken string `json:"page_token,omitempty" jsonschema:"next_page_token from a previous call"`
+}
+
+type mcpContactInput struct {
+	ContactID string `json:"contact_id" jsonschema:"Contact ID (crd_...)"`
+}
+
+type mcpDraftInput struct {
+	DraftID string `json:"draft_id" jsonschema:"Draft ID (msg_...)"`
+}
+
+type mcpNoInput struct{}
+
+// mcpMessage is a message with its HTML body converted to Markdown, which is
+// far cheaper for a model to read.
+type mcpMessage struct {
+	api.Message
+
+	Body         string `json:"body,omitempty"`
+	BodyMarkdown string `json:"body_markdown,omitempty"`
+}
+
+func newMCPMessage(msg api.Message) mcpMessage {
+	out := mcpMessage{Message: msg, BodyMarkdown: msg.Text}
+
+	if md, err := markdown.ToMarkdown(msg.Body); err == nil && strings.TrimSpace(md) != "" {
+		out.BodyMarkdown = md
+	} else if out.BodyMarkdown == "" {
+		out.Body = msg.Body
+	}
+
+	return out
+}
+
+func (t *mcpTools) addReadTools(server *mcp.Server) {
+	addMCPTool(server, mcpRead, "list_conversations", "List conversations, most recent first, optionally filtered by inbox, tag and status", t.listConversations)
+	addMCPTool(server, mcpRead, "search_conversations", "Search conversations with Front's search syntax", t.searchConversations)
+	addMCPTool(server, mcpRead, "get_conversation", "Get a conversation with its status, assignee, tags and inboxes", t.conversation)
+	addMCPTool(server, mcpRead, "list_messages", "List the messages of a conversation, newest first, with bodies as Markdown", t.messages)
+	addMCPTool(server, mcpRead, "get_message", "Get a message with its body as Markdown", t.message)
+	addMCPTool(server, mcpRead, "list_comments", "List the internal comments of a conversation", t.comments)
+	addMCPTool(server, mcpRead, "list_drafts", "List the drafts of a conversation", t.drafts)
+	addMCPTool(server, mcpRead, "list_contacts", "List contacts", t.contacts)
+	addMCPTool(server, mcpRead, "get_contact", "Get a contact with its handles", t.contact)
+	addMCPTool(server, mcpRead, "list_tags", "List all tags", func(ctx context.Context, _ mcpNoInput) (any, error) {
+		return t.client.ListTags(ctx)
+	})
+	addMCPTool(server, mcpRead, "list_inboxes", "List all inboxes", func(ctx context.Context, _ mcpNoInput) (any, error) {
+		return t.client.ListInboxes(ctx)
+	})
+	addMCPTool(server, mcpRead, "list_channels", "List all channels (addresses messages can be sent from)", func(ctx context.Context, _ mcpNoInput) (any, error) {
+		return t.client.ListChannels(ctx)
+	})
+}
+
+func (t *mcpTools) listConversations(ctx context.Context, in mcpListConversationsInput) (any, error) {
+	opts := api.ListConversationsOptions{Statuses: api.ParseStatus(in.Status), Limit: mcpLimit(in.Limit)}
+
+	var err error
+
+	if opts.InboxID, err = t.resolver.inboxID(ctx, in.Inbox); err != nil {
+		return nil, err
+	}
+
+	if opts.TagID, err = t.resolver.tagID(ctx, in.Tag); err != nil {
+		return nil, err
+	}
+
+	return listPage[api.Conversation](ctx, t.client, "/conversations?"+opts.Query(), in.PageToken)
+}
+
+func (t *mcpTools) searchConversations(ctx context.Context, in mcpSearchInput) (any, error) {
+	if strings.TrimSpace(in.Query) == "" {
+		return nil, fmt.Errorf("query is required")
+	}
+
+	params := url.Values{}
+	params.Set("q", in.Query)
+	params.Set("limit", strconv.Itoa(mcpLimit(in.Limit)))
+
+	return listPage[api.Conversation](ctx, t.client, "/conversations/search?"+params.Encode(), in.PageToken)
+}
+
+func (t *mcpTools) conversation(ctx context.Context, in mcpConversationInput) (any, error) {
+	id, err := mcpPathID(in.ConversationID, "cnv_")
+	if err != nil {
+		return nil, err
+	}
+
+	return t.client.GetConversation(ctx, id)
+}
+
+func (t *mcpTools) messages(ctx context.Context, in mcpMessagesInput) (any, error) {
+	id, err := mcpPathID(in.ConversationID, "cnv_")
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("/conversations/%s/messages?limit=%d", id, mcpLimit(in.Limit))
+
+	page, err := listPage[api.Message](ctx, t.client, path, in.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &mcpPage[mcpMessage]{Results: make([]mcpMessage, len(page.Results)), NextPageToken: page.NextPageToken}
+	for i, msg := range page.Results {
+		out.Results[i] = newMCPMessage(msg)
+	}
+
+	return out, nil
+}
+
+func (t *mcpTools) message(ctx context.Context, in mcpMessageInput) (any, error) {
+	id, err := mcpPathID(in.MessageID, "msg_")
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := t.client.GetMessage(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return newMCPMessage(*msg), nil
+}
+
+func (t *mcpTools) comments(ctx context.Context, in mcpCommentsInput) (any, error) {
+	id, err := mcpPathID(in.ConversationID, "cnv_")
+	if err != nil {
+		return nil, err
+	}
+
+	return listPage[api.Comment](ctx, t.client, fmt.Sprintf("/conversations/%s/comments", id), in.PageToken)
+}
+
+func (t *mcpTools) drafts(ctx context.Context, in mcpConversationInput) (any, error) {
+	id, err := mcpPathID(in.ConversationID, "cnv_")
+	if err != nil {
+		return nil, err
+	}
+
+	return listPage[api.Draft](ctx, t.client, fmt.Sprintf("/conversations/%s/drafts", id), "")
+}
+
+func (t *mcpTools) contacts(ctx context.Context, in mcpListContactsInput) (any, error) {
+	return listPage[api.Contact](ctx, t.client, "/contacts?limit="+strconv.Itoa(mcpLimit(in.Limit)), in.PageToken)
+}
+
+func (t *mcpTools) contact(ctx context.Context, in mcpContactInput) (any, error) {
+	id, err := mcpPathID(in.ContactID, "crd_")
+	if err != nil {
+		return nil, err
+	}
+
+	return t.client.GetContact(ctx, id)
+}
+
+type mcpCreateDraftInput struct {
+	ConversationID string   `json:"conversation_id,omitempty" jsonschema:"Conversation to draft a reply in (cnv_...)"`
+	Channel        string   `json:"channel,omitempty" jsonschema:"Channel to draft a new message from (ID, name or address); required without conversation_id"`
+	To             []string `json:"to,omitempty" jsonschema:"Recipient addresses"`
+	Cc             []string `json:"cc,omitempty" jsonschema:"Cc addresses"`
+	Bcc            []string `json:"bcc,omitempty" jsonschema:"Bcc addresses"`
+	Subject        string   `json:"subject,omitempty" jsonschema:"Subject"`
+	Body           string   `json:"body" jsonschema:"Draft body"`
+	Format         string   `json:"format,omitempty" jsonschema:"Body format: markdown (default), html or text"`
+}
+
+type mcpCommentInput struct {
+	ConversationID string `json:"conversation_id" jsonschema:"Conversation ID (cnv_...)"`
+	Body           string `json:"body" jsonschema:"Comment body; @mentions notify teammates"`
+}
+
+type mcpTagInput struct {
+	ConversationID string   `json:"conversation_id" jsonschema:"Conversation ID (cnv_...)"`
+	Tags           []string `json:"tags" jsonschema:"Tags (IDs or names)"`
+}
+
+type mcpResult struct {
+	OK bool   `json:"ok"`
+	ID string `json:"id,omitempty"`
+}
+
+func (t *mcpTools) addWriteTools(server *mcp.Server) {
+	addMCPTool(server, mcpWrite, "create_draft", "Create a draft reply in a conversation, or a new message draft on a channel, for a teammate to review and send", t.createDraft)
+	addMCPTool(server, mcpWrite, "add_comment", "Add an internal comment to a conversation (not visible to the customer)", t.addComment)
+	addMCPTool(server, mcpWrite, "tag_conversation", "Add tags to a conversation", t.tagConversation)
+	addMCPTool(server, mcpWrite, "untag_conversation", "Remove tags from a conversation", t.untagConversation)
+	addMCPTool(server, mcpWrite, "archive_conversation", "Archive a conversation", func(ctx context.Context, in mcpConversationInput) (any, error) {
+		return t.setStatus(ctx, in.ConversationID, "archived")
+	})
+	addMCPTool(server, mcpWrite, "reopen_conversation", "Reopen an archived conversation", func(ctx context.Context, in mcpConversationInput) (any, error) {
+		return t.setStatus(ctx, in.ConversationID, "open")
+	})
+}
+
+// composedRequest builds the request body shared by drafts and messages.
+func composedRequest(body, format string, c composition) (map[string]any, error) {
+	if strings.TrimSpace(body) == "" {
+		return nil, fmt.Errorf("body is required")
+	}
+
+	if format == "" {
+		format = "markdown"
+	}
+
+	html, err := markdown.Render(body, format)
+	if err != nil {
+		return nil, err
+	}
+
+	req := map[string]any{"body": html}
+	if len(c.To) > 0 {
+		req["to"] = c.To
+	}
+
+	addComposedFields(req, c)
+
+	return req, nil
+}
+
+func (t *mcpTools) createDraft(ctx context.Context, in mcpCreateDraftInput) (any, error) {
+	req, err := composedRequest(in.Body, in.Format, composition{To: in.To, Cc: in.Cc, Bcc: in.Bcc, Subject: in.Subject})
+	if err != nil {
+		return nil, err
+	}
+
+	var path string
+
+	switch {
+	case in.ConversationID != "":
+		id, err := mcpPathID(in.ConversationID, "cnv_")
+		if err != nil {
+			return nil, err
+		}
+
+		path = fmt.Sprintf("/conversations/%s/drafts", id)
+	case in.Channel != "":
+		channelID, err := t.resolver.channelID(ctx, in.Channel)
+		if err != nil {
+			return nil, err
+		}
+
+		path = fmt.Sprintf("/channels/%s/drafts", url.PathEscape(channelID))
+	default:
+		return nil, fmt.Errorf("either conversation_id or channel is required")
+	}
+
+	var draft api.Draft
+	if err := t.client.Post(ctx, path, req, &draft); err != nil {
+		return nil, err
+	}
+
+	return &draft, nil
+}
+
+func (t *mcpTools) addComment(ctx context.Context, in mcpCommentInput) (any, error) {
+	if strings.TrimSpace(in.Body) == "" {
+		return nil, fmt.Errorf("body is required")
+	}
+
+	id, err := mcpPathID(in.ConversationID, "cnv_")
+	if err != nil {
+		return nil, err
+	}
+
+	var comment api.Comment
+	if err := t.client.Post(ctx, fmt.Sprintf("/conversations/%s/comments", id), map[string]string{"body": in.Body}, &comment); err != nil {
+		return nil, err
+	}
+
+	return &comment, nil
+}
+
+func (t *mcpTools) tagIDs(ctx context.Context, refs []string) ([]string, error) {
+	if len(refs) == 0 {
+		return nil, fmt.Errorf("at least one tag is required")
+	}
+
+	ids := make([]string, 0, len(refs))
+
+	for _, ref := range refs {
+		id, err := t.resolver.tagID(ctx, ref)
+		if err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+func (t *mcpTools) tagConversation(ctx context.Context, in mcpTagInput) (any, error) {
+	convID, err := mcpPathID(in.ConversationID, "cnv_")
+	if err != nil {
+		return nil, err
+	}
+
+	ids, err := t.tagIDs(ctx, in.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := t.client.Post(ctx, fmt.Sprintf("/conversations/%s/tags", convID), map[string][]string{"tag_ids": ids}, nil); err != nil {
+		return nil, err
+	}
+
+	return mcpResult{OK: true, ID: in.ConversationID}, nil
+}
+
+func (t *mcpTools) untagConversation(ctx context.Context, in mcpTagInput) (any, error) {
+	convID, err := mcpPathID(in.ConversationID, "cnv_")
+	if err != nil {
+		return nil, err
+	}
+
+	ids, err := t.tagIDs(ctx, in.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range ids {
+		if err := t.client.Delete(ctx, fmt.Sprintf("/conversations/%s/tags/%s", convID, url.PathEscape(id))); err != nil {
+			return nil, err
+		}
+	}
+
+	return mcpResult{OK: true, ID: in.ConversationID}, nil
+}
+
+func (t *mcpTools) setStatus(ctx context.Context, convID, status string) (any, error) {
+	id, err := mcpPathID(convID, "cnv_")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := t.client.Patch(ctx, "/conversations/"+id, map[string]string{"status": status}, nil); err != nil {
+		return nil, err
+	}
+
+	return mcpResult{OK: true, ID: convID}, nil
+}
+
+type mcpSendInput struct {
+	Channel string   `json:"channel" jsonschema:"Channel to send from (ID, name or address)"`
+	To      []string `json:"to" jsonschema:"Recipient addresses"`
+	Cc      []string `json:"cc,omitempty" jsonschema:"Cc addresses"`
+	Bcc     []string `json:"bcc,omitempty" jsonschema:"Bcc addresses"`
+	Subject string   `json:"subject,omitempty" jsonschema:"Subject"`
+	Body    string   `json:"body" jsonschema:"Message body"`
+	Format  string   `json:"format,omitempty" jsonschema:"Body format: markdown (default), html or text"`
+}
+
+type mcpReplyInput struct {
+	ConversationID string   `json:"conversation_id" jsonschema:"Conversation to reply to (cnv_...)"`
+	InReplyTo      string   `json:"in_reply_to,omitempty" jsonschema:"Message ID to reply to, for threading"`
+	To             []string `json:"to,omitempty" jsonschema:"Override the recipients"`
+	Cc             []string `json:"cc,omitempty" jsonschema:"Cc addresses"`
+	Bcc            []string `json:"bcc,omitempty" jsonschema:"Bcc addresses"`
+	Body           string   `json:"body" jsonschema:"Reply body"`
+	Format         string   `json:"format,omitempty" jsonschema:"Body format: markdown (default), html or text"`
+}
+
+func (t *mcpTools) addSendTools(server *mcp.Server) {
+	addMCPTool(server, mcpDestructive, "send_message", "Send a new message from a channel. The message is delivered immediately.", t.sendMessage)
+	addMCPTool(server, mcpDestructive, "reply_to_conversation", "Send a reply in a conversation. The reply is delivered immediately.", t.reply)
+}
+
+func (t *mcpTools) sendMessage(ctx context.Context, in mcpSendInput) (any, error) {
+	if len(in.To) == 0 {
+		return nil, fmt.Errorf("at least one recipient is required")
+	}
+
+	req, err := composedRequest(in.Body, in.Format, composition{To: in.To, Cc: in.Cc, Bcc: in.Bcc, Subject: in.Subject})
+	if err != nil {
+		return nil, err
+	}
+
+	channelID, err := t.resolver.channelID(ctx, in.Channel)
+	if err != nil {
+		return nil, err
+	}
+
+	var result map[string]any
+	if err := sendBody(ctx, t.client, http.MethodPost, fmt.Sprintf("/channels/%s/messages", url.PathEscape(channelID)), req, nil, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (t *mcpTools) reply(ctx context.Context, in mcpReplyInput) (any, error) {
+	req, err := composedRequest(in.Body, in.Format, composition{To: in.To, Cc: in.Cc, Bcc: in.Bcc})
+	if err != nil {
+		return nil, err
+	}
+
+	convID, err := mcpPathID(in.ConversationID, "cnv_")
+	if err != nil {
+		return nil, err
+	}
+
+	req["type"] = "reply"
+
+	if in.InReplyTo != "" {
+		req["in_reply_to_message_id"] = in.InReplyTo
+	}
+
+	var result map[string]any
+	if err := sendBody(ctx, t.client, http.MethodPost, fmt.Sprintf("/conversations/%s/messages", convID), req, nil, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (t *mcpTools) addTrashTools(server *mcp.Server) {
+	addMCPTool(server, mcpDestructive, "trash_conversation", "Move a conversation to the trash", func(ctx context.Context, in mcpConversationInput) (any, error) {
+		return t.setStatus(ctx, in.ConversationID, "trashed")
+	})
+}
+
+func (t *mcpTools) addDeleteTools(server *mcp.Server) {
+	addMCPTool(server, mcpDestructive, "delete_draft", "Delete a draft", func(ctx context.Context, in mcpDraftInput) (any, error) {
+		id, err := mcpPathID(in.DraftID, "msg_", "drf_")
+		if err != nil {
+			return nil, err
+		}
+
+		if err := t.client.Delete(ctx, "/drafts/"+id); err != nil {
+			return nil, err
+		}
+
+		return mcpResult{OK: true, ID: in.DraftID}, nil
+	})
+	addMCPTool(server, mcpDestructive, "delete_contact", "Delete a contact", func(ctx context.Context, in mcpContactInput) (any, error) {
+		id, err := mcpPathID(in.ContactID, "crd_")
+		if err != nil {
+			return nil, err
+		}
+
+		if err := t.client.Delete(ctx, "/contacts/"+id); err != nil {
+			return nil, err
+		}
+
+		return mcpResult{OK: true, ID: in.ContactID}, nil
+	})
+}
@@ -3,9 +3,11 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"strings"
 
+	"github.com/dedene/frontapp-cli/internal/api"
 	"github.com/dedene/frontapp-cli/internal/config"
 	"github.com/dedene/frontapp-cli/internal/errfmt"
 	"github.com/dedene/frontapp-cli/internal/markdown"
@@ -46,7 +48,11 @@ func (c *MsgGetCmd) Run(flags *RootFlags) error {
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, msg)
+		return output.Write(os.Stdout, mode, msg)
+	}
+
+	if mode.Columnar() {
+		return output.WriteItem(os.Stdout, mode, output.MessageColumns, *msg)
 	}
 
 	direction := "Outbound"
@@ -100,11 +106,15 @@ func (c *MsgGetCmd) Run(flags *RootFlags) error {
 }
 
 type MsgSendCmd struct {
-	Channel  string `required:"" help:"Channel ID to send from"`
-	To       string `required:"" help:"Recipient address"`
+	Channel  string `help:"Channel to send from (ID, name or address)"`
+	To       string `help:"Recipient address"`
 	Subject  string `help:"Message subject"`
 	Body     string `help:"Message body"`
 	BodyFile string `help:"Read body from file" type:"existingfile"`
+	Edit     bool   `help:"Compose in $EDITOR with To/Cc/Bcc/Subject/Channel headers"`
+
+	BodyFormatFlags `embed:""`
+	AttachFlags     `embed:""`
 }
 
 func (c *MsgSendCmd) Run(flags *RootFlags) error {
@@ -120,6 +130,11 @@ func (c *MsgSendCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
+	files, err := api.LoadAttachments(c.Attach)
+	if err != nil {
+		return err
+	}
+
 	body := c.Body
 	if c.BodyFile != "" {
 		data, err := os.ReadFile(c.BodyFile)
@@ -130,28 +145,52 @@ func (c *MsgSendCmd) Run(flags *RootFlags) error {
 		body = string(data)
 	}
 
-	if body == "" {
-		return fmt.Errorf("body is required (use --body or --body-file)")
+	msg := composition{Subject: c.Subject, Channel: c.Channel, Body: body}
+	if c.To != "" {
+		msg.To = []string{c.To}
 	}
 
-	req := map[string]any{
-		"to":   []string{c.To},
-		"body": body,
+	if c.Edit {
+		msg, err = editComposition(msg, messageHeaders, "", "")
+		if err != nil {
+			return err
+		}
+	}
+
+	if msg.Channel == "" || len(msg.To) == 0 {
+		return fmt.Errorf("--channel and --to are required (or set them with --edit)")
 	}
 
-	if c.Subject != "" {
-		req["subject"] = c.Subject
+	if msg.Body == "" {
+		return fmt.Errorf("body is required (use --body, --body-file or --edit)")
 	}
 
+	msg.Body, err = c.render(msg.Body, c.Edit)
+	if err != nil {
+		return err
+	}
+
+	req := map[string]any{
+		"to":   msg.To,
+		"body": msg.Body,
+	}
+
+	addComposedFields(req, msg)
+
 	var result map[string]any
-	if err := client.Post(ctx, fmt.Sprintf("/channels/%s/messages", c.Channel), req, &result); err != nil {
+	channelID, err := newResolver(client, flags).channelID(ctx, msg.Channel)
+	if err != nil {
+		return err
+	}
+
+	if err := sendBody(ctx, client, http.MethodPost, fmt.Sprintf("/channels/%s/messages", channelID), req, files, &result); err != nil {
 		fmt.Fprint(os.Stderr, errfmt.Format(err))
 
 		return err
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, result)
+		return output.Write(os.Stdout, mode, result)
 	}
 
 	fmt.Fprintln(os.Stdout, "Message sent successfully")
@@ -164,6 +203,10 @@ type MsgReplyCmd struct {
 	Body      string `help:"Reply body"`
 	BodyFile  string `help:"Read body from file" type:"existingfile"`
 	InReplyTo string `help:"Message ID to reply to (for threading)"`
+	Edit      bool   `help:"Compose in $EDITOR with the previous message quoted"`
+
+	BodyFormatFlags `embed:""`
+	AttachFlags     `embed:""`
 }
 
 func (c *MsgReplyCmd) Run(flags *RootFlags) error {
@@ -179,6 +222,11 @@ func (c *MsgReplyCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
+	files, err := api.LoadAttachments(c.Attach)
+	if err != nil {
+		return err
+	}
+
 	body := c.Body
 	if c.BodyFile != "" {
 		data, err := os.ReadFile(c.BodyFile)
@@ -189,28 +237,55 @@ func (c *MsgReplyCmd) Run(flags *RootFlags) error {
 		body = string(data)
 	}
 
-	if body == "" {
-		return fmt.Errorf("body is required (use --body or --body-file)")
+	msg := composition{Body: body}
+
+	if c.Edit {
+		quote, err := replyQuote(ctx, client, c.ConvID, c.InReplyTo)
+		if err != nil {
+			fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+			return err
+		}
+
+		msg, err = editComposition(msg, replyHeaders, replyHint, quote)
+		if err != nil {
+			return err
+		}
+	}
+
+	if msg.Body == "" {
+		return fmt.Errorf("body is required (use --body, --body-file or --edit)")
+	}
+
+	msg.Body, err = c.render(msg.Body, c.Edit)
+	if err != nil {
+		return err
 	}
 
 	req := map[string]any{
-		"body": body,
+		"body": msg.Body,
 		"type": "reply",
 	}
 
+	if len(msg.To) > 0 {
+		req["to"] = msg.To
+	}
+
+	addComposedFields(req, msg)
+
 	if c.InReplyTo != "" {
 		req["in_reply_to_message_id"] = c.InReplyTo
 	}
 
 	var result map[string]any
-	if err := client.Post(ctx, fmt.Sprintf("/conversations/%s/messages", c.ConvID), req, &result); err != nil {
+	if err := sendBody(ctx, client, http.MethodPost, fmt.Sprintf("/conversations/%s/messages", c.ConvID), req, files, &result); err != nil {
 		fmt.Fprint(os.Stderr, errfmt.Format(err))
 
 		return err
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, result)
+		return output.Write(os.Stdout, mode, result)
 	}
 
 	fmt.Fprintln(os.Stdout, "Reply sent successfully")
@@ -243,7 +318,7 @@ func (c *MsgAttachmentsCmd) Run(flags *RootFlags) error {
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, map[string]any{"attachments": msg.Attachments})
+		return output.Write(os.Stdout, mode, map[string]any{"attachments": msg.Attachments})
 	}
 
 	if len(msg.Attachments) == 0 {
@@ -252,14 +327,7 @@ func (c *MsgAttachmentsCmd) Run(flags *RootFlags) error {
 		return nil
 	}
 
-	tbl := output.NewTableWriter(os.Stdout, mode.Plain)
-	tbl.AddRow("ID", "FILENAME", "TYPE", "SIZE")
-
-	for _, att := range msg.Attachments {
-		tbl.AddRow(att.ID, att.Filename, att.ContentType, fmt.Sprintf("%d", att.Size))
-	}
-
-	return tbl.Flush()
+	return output.WriteRows(os.Stdout, mode, output.AttachmentColumns, output.AttachmentFields, msg.Attachments)
 }
 
 type MsgAttachmentCmd struct {
@@ -18,38 +18,98 @@ func resolveOutputMode(flags *RootFlags) (output.Mode, error) {
 	if cfg.DefaultOutput != "" {
 		switch cfg.DefaultOutput {
 		case "json":
-			mode.JSON = true
-			mode.Plain = false
+			mode = output.Mode{JSON: true}
+		case "ndjson":
+			mode = output.Mode{JSON: true, NDJSON: true}
+		case "yaml":
+			mode = output.Mode{JSON: true, YAML: true}
 		case "plain":
-			mode.Plain = true
-			mode.JSON = false
+			mode = output.Mode{Plain: true}
+		case "csv":
+			mode = output.Mode{CSV: true}
 		default:
 		}
 	}
 
 	envMode := output.FromEnv()
 	if envMode.JSON {
-		mode.JSON = true
-		mode.Plain = false
+		mode = output.Mode{JSON: true}
+	}
+	if envMode.NDJSON {
+		mode = output.Mode{JSON: true, NDJSON: true}
+	}
+	if envMode.YAML {
+		mode = output.Mode{JSON: true, YAML: true}
 	}
 	if envMode.Plain {
-		mode.Plain = true
-		mode.JSON = false
+		mode = output.Mode{Plain: true}
+	}
+	if envMode.CSV {
+		mode = output.Mode{CSV: true}
 	}
 
 	if flags.JSON {
-		mode.JSON = true
-		mode.Plain = false
+		mode = output.Mode{JSON: true}
+	}
+
+	if flags.NDJSON {
+		mode = output.Mode{JSON: true, NDJSON: true}
+	}
+
+	if flags.YAML {
+		mode = output.Mode{JSON: true, YAML: true}
 	}
 
 	if flags.Plain {
-		mode.Plain = true
-		mode.JSON = false
+		mode = output.Mode{Plain: true}
+	}
+
+	if flags.CSV {
+		mode = output.Mode{CSV: true}
 	}
 
 	if mode.JSON && mode.Plain {
 		return output.Mode{}, fmt.Errorf("cannot use both JSON and plain output")
 	}
 
+	if flags.JQ != "" {
+		if flags.Plain || flags.CSV || len(flags.Fields) > 0 || flags.Template != "" {
+			return output.Mode{}, fmt.Errorf("--jq cannot be combined with --plain, --csv, --fields or --template")
+		}
+
+		filter, err := output.CompileFilter(flags.JQ)
+		if err != nil {
+			return output.Mode{}, err
+		}
+
+		// A table default from config or environment gives way to JSON.
+		mode.Plain = false
+		mode.CSV = false
+		mode.JSON = true
+		mode.JQ = filter
+	}
+
+	if len(flags.Fields) > 0 || flags.Template != "" {
+		if flags.JSON || flags.NDJSON || flags.YAML {
+			return output.Mode{}, fmt.Errorf("--fields and --template cannot be combined with --json, --ndjson or --yaml")
+		}
+
+		if len(flags.Fields) > 0 && flags.Template != "" {
+			return output.Mode{}, fmt.Errorf("cannot use both --fields and --template")
+		}
+
+		if flags.Template != "" && mode.CSV {
+			return output.Mode{}, fmt.Errorf("cannot use both --template and CSV output")
+		}
+
+		// Explicit column or template selection wins over a structured
+		// default from config or environment.
+		mode.JSON = false
+		mode.NDJSON = false
+		mode.YAML = false
+		mode.Fields = flags.Fields
+		mode.Template = flags.Template
+	}
+
 	return mode, nil
 }
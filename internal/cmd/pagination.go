@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+	"github.com/dedene/frontapp-cli/internal/output"
+)
+
+// PaginationFlags are embedded by list commands that follow Front's cursor pagination.
+type PaginationFlags struct {
+	All       bool   `help:"Fetch all pages (--limit sets the page size)"`
+	MaxPages  int    `help:"Maximum number of pages to fetch" name:"max-pages"`
+	PageToken string `help:"Resume from a page token or _pagination.next URL" name:"page-token"`
+}
+
+func (p PaginationFlags) pageOptions() api.PageOptions {
+	if p.MaxPages > 0 {
+		return api.PageOptions{MaxPages: p.MaxPages}
+	}
+
+	if p.All {
+		return api.PageOptions{}
+	}
+
+	return api.PageOptions{MaxPages: 1}
+}
+
+// fetchList fetches a list endpoint honoring the pagination flags and merges
+// every fetched page into a single response. Pagination.Next is set when
+// more pages remain.
+//
+// In NDJSON mode results are written to stdout as each page arrives instead,
+// and the returned response carries no results.
+func fetchList[T any](ctx context.Context, client *api.Client, path string, pf PaginationFlags, mode output.Mode) (*api.ListResponse[T], error) {
+	resp := &api.ListResponse[T]{Results: make([]T, 0)}
+
+	next, err := api.Paginate(ctx, client, api.WithPageToken(path, pf.PageToken), pf.pageOptions(), func(items []T) error {
+		if mode.NDJSON {
+			return output.Write(os.Stdout, mode, items)
+		}
+
+		resp.Results = append(resp.Results, items...)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Pagination.Next = next
+
+	if mode.NDJSON {
+		printMoreResultsHint(next)
+	}
+
+	return resp, nil
+}
+
+// printMoreResultsHint tells table users how to continue past the fetched pages.
+func printMoreResultsHint(next string) {
+	if next == "" {
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "More results available: use --all or --page-token %s\n", api.PageToken(next))
+}
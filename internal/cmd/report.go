@@ -0,0 +1,403 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+	"github.com/dedene/frontapp-cli/internal/errfmt"
+	"github.com/dedene/frontapp-cli/internal/output"
+	"github.com/dedene/frontapp-cli/internal/report"
+	"github.com/dedene/frontapp-cli/internal/store"
+)
+
+type ReportCmd struct {
+	ResponseTimes ReportResponseTimesCmd `cmd:"" name:"response-times" help:"First-response time, resolution time and messages per conversation"`
+	Volume        ReportVolumeCmd        `cmd:"" help:"Conversations created and messages sent and received"`
+	Backlog       ReportBacklogCmd       `cmd:"" help:"Open conversations and how long they have been waiting"`
+}
+
+// ReportFlags are shared by every report.
+type ReportFlags struct {
+	GroupBy     string `help:"Group rows by none, inbox, tag, assignee, day or week" name:"group-by" enum:"none,inbox,tag,assignee,day,week" default:"none"`
+	Percentiles []int  `help:"Percentiles to compute" default:"50,90,95" sep:","`
+	Offline     bool   `help:"Read from the local database from 'frontcli sync' instead of Front"`
+}
+
+func (f ReportFlags) options() (report.Options, error) {
+	percentiles, err := report.ParsePercentiles(f.Percentiles)
+	if err != nil {
+		return report.Options{}, err
+	}
+
+	return report.Options{GroupBy: f.GroupBy, Percentiles: percentiles}, nil
+}
+
+// ReportRangeFlags bound the conversations a report covers.
+type ReportRangeFlags struct {
+	After  string `help:"Start of the range: Unix seconds, RFC3339 or YYYY-MM-DD (default: 7 days ago)"`
+	Before string `help:"End of the range (default: now)"`
+}
+
+func (f ReportRangeFlags) resolve(now time.Time) (report.Range, error) {
+	after, err := parseSearchTime(f.After)
+	if err != nil {
+		return report.Range{}, fmt.Errorf("invalid --after: %w", err)
+	}
+
+	before, err := parseSearchTime(f.Before)
+	if err != nil {
+		return report.Range{}, fmt.Errorf("invalid --before: %w", err)
+	}
+
+	if after == 0 {
+		after = float64(now.AddDate(0, 0, -7).Unix())
+	}
+
+	if before == 0 {
+		before = float64(now.Unix())
+	}
+
+	if before <= after {
+		return report.Range{}, fmt.Errorf("--before must be later than --after")
+	}
+
+	return report.Range{After: after, Before: before}, nil
+}
+
+type ReportResponseTimesCmd struct {
+	ReportRangeFlags `embed:""`
+	ReportFlags      `embed:""`
+}
+
+func (c *ReportResponseTimesCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	opts, err := c.options()
+	if err != nil {
+		return err
+	}
+
+	rng, err := c.resolve(time.Now())
+	if err != nil {
+		return err
+	}
+
+	convs, err := loadReportConversations(ctx, flags, c.Offline, rng)
+	if err != nil {
+		return err
+	}
+
+	// Only conversations that started in the range: older ones would
+	// report response times from before it.
+	created := convs[:0]
+	for _, conv := range convs {
+		if rng.Contains(conv.CreatedAt) {
+			created = append(created, conv)
+		}
+	}
+
+	rows, err := report.ComputeResponseTimes(created, opts)
+	if err != nil {
+		return err
+	}
+
+	cols := responseTimeColumns(opts.Percentiles)
+
+	return writeReport(mode, rng, opts, rows, cols, defaultReportFields(cols))
+}
+
+func responseTimeColumns(percentiles []int) output.Columns[report.ResponseTimes] {
+	cols := output.Columns[report.ResponseTimes]{
+		{Name: "group", Header: "GROUP", Value: func(r report.ResponseTimes) string { return r.Group }},
+		{Name: "conversations", Header: "CONVERSATIONS", Value: func(r report.ResponseTimes) string { return strconv.Itoa(r.Conversations) }},
+		{Name: "responded", Header: "RESPONDED", Value: func(r report.ResponseTimes) string { return strconv.Itoa(r.Responded) }},
+	}
+	cols = append(cols, statColumns("frt", "FIRST RESPONSE", percentiles, func(r report.ResponseTimes) report.Stats { return r.FirstResponse }, report.FormatDuration)...)
+	cols = append(cols, output.Column[report.ResponseTimes]{Name: "resolved", Header: "RESOLVED", Value: func(r report.ResponseTimes) string { return strconv.Itoa(r.Resolved) }})
+	cols = append(cols, statColumns("resolution", "RESOLUTION", percentiles, func(r report.ResponseTimes) report.Stats { return r.Resolution }, report.FormatDuration)...)
+	cols = append(cols, statColumns("messages", "MESSAGES", nil, func(r report.ResponseTimes) report.Stats { return r.Messages }, formatCount)...)
+
+	return cols
+}
+
+type ReportVolumeCmd struct {
+	ReportRangeFlags `embed:""`
+	ReportFlags      `embed:""`
+}
+
+func (c *ReportVolumeCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	opts, err := c.options()
+	if err != nil {
+		return err
+	}
+
+	rng, err := c.resolve(time.Now())
+	if err != nil {
+		return err
+	}
+
+	convs, err := loadReportConversations(ctx, flags, c.Offline, rng)
+	if err != nil {
+		return err
+	}
+
+	rows, err := report.ComputeVolume(convs, rng, opts)
+	if err != nil {
+		return err
+	}
+
+	cols := output.Columns[report.Volume]{
+		{Name: "group", Header: "GROUP", Value: func(r report.Volume) string { return r.Group }},
+		{Name: "conversations", Header: "CONVERSATIONS", Value: func(r report.Volume) string { return strconv.Itoa(r.Conversations) }},
+		{Name: "inbound", Header: "INBOUND", Value: func(r report.Volume) string { return strconv.Itoa(r.Inbound) }},
+		{Name: "outbound", Header: "OUTBOUND", Value: func(r report.Volume) string { return strconv.Itoa(r.Outbound) }},
+		{Name: "per_conversation", Header: "MSGS/CONV", Value: func(r report.Volume) string { return formatCount(r.PerConv) }},
+	}
+
+	return writeReport(mode, rng, opts, rows, cols, cols.Names())
+}
+
+type ReportBacklogCmd struct {
+	ReportFlags `embed:""`
+}
+
+func (c *ReportBacklogCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	opts, err := c.options()
+	if err != nil {
+		return err
+	}
+
+	var convs []api.Conversation
+
+	if c.Offline {
+		db, _, err := openAccountDB(ctx, flags, true)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		if convs, err = db.Conversations(ctx, store.ConversationFilter{Open: true}); err != nil {
+			return err
+		}
+	} else {
+		client, err := getClient(flags)
+		if err != nil {
+			return err
+		}
+
+		list := api.ListConversationsOptions{Statuses: api.ParseStatus("open"), Limit: 100}
+
+		_, err = api.Paginate(ctx, client, "/conversations?"+list.Query(), api.PageOptions{}, func(page []api.Conversation) error {
+			convs = append(convs, page...)
+
+			return nil
+		})
+		if err != nil {
+			fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+			return err
+		}
+	}
+
+	rows, err := report.ComputeBacklog(convs, time.Now(), opts)
+	if err != nil {
+		return err
+	}
+
+	cols := output.Columns[report.Backlog]{
+		{Name: "group", Header: "GROUP", Value: func(r report.Backlog) string { return r.Group }},
+		{Name: "open", Header: "OPEN", Value: func(r report.Backlog) string { return strconv.Itoa(r.Open) }},
+		{Name: "unassigned", Header: "UNASSIGNED", Value: func(r report.Backlog) string { return strconv.Itoa(r.Unassigned) }},
+		{Name: "waiting", Header: "WAITING", Value: func(r report.Backlog) string { return strconv.Itoa(r.Waiting) }},
+	}
+	cols = append(cols, statColumns("age", "AGE", opts.Percentiles, func(r report.Backlog) report.Stats { return r.Age }, report.FormatDuration)...)
+	cols = append(cols, output.Column[report.Backlog]{Name: "oldest", Header: "OLDEST", Value: func(r report.Backlog) string { return r.Oldest }})
+
+	return writeReport(mode, report.Range{}, opts, rows, cols, defaultReportFields(cols))
+}
+
+// statColumns returns the average, percentile and maximum columns of a
+// Stats field, e.g. frt_avg, frt_p50, frt_max.
+func statColumns[T any](name, header string, percentiles []int, stats func(T) report.Stats, format func(float64) string) output.Columns[T] {
+	value := func(get func(report.Stats) float64) func(T) string {
+		return func(row T) string {
+			s := stats(row)
+			if s.Count == 0 {
+				return "-"
+			}
+
+			return format(get(s))
+		}
+	}
+
+	cols := output.Columns[T]{
+		{Name: name + "_avg", Header: header + " AVG", Value: value(func(s report.Stats) float64 { return s.Avg })},
+	}
+
+	for _, p := range percentiles {
+		cols = append(cols, output.Column[T]{
+			Name:   name + "_p" + strconv.Itoa(p),
+			Header: header + " P" + strconv.Itoa(p),
+			Value:  value(func(s report.Stats) float64 { return s.Percentile(p) }),
+		})
+	}
+
+	return append(cols, output.Column[T]{
+		Name:   name + "_max",
+		Header: header + " MAX",
+		Value:  value(func(s report.Stats) float64 { return s.Max }),
+	})
+}
+
+// defaultReportFields leaves the _max columns out of table output; they
+// are still available with --fields.
+func defaultReportFields[T any](cols output.Columns[T]) []string {
+	var names []string
+
+	for _, name := range cols.Names() {
+		if !strings.HasSuffix(name, "_max") {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+func formatCount(v float64) string {
+	return strconv.FormatFloat(v, 'f', 1, 64)
+}
+
+func writeReport[T any](mode output.Mode, rng report.Range, opts report.Options, rows []T, cols output.Columns[T], fields []string) error {
+	if mode.JSON {
+		if mode.NDJSON {
+			return output.Write(os.Stdout, mode, rows)
+		}
+
+		result := map[string]any{"group_by": opts.GroupBy, "rows": rows}
+		if rng.After > 0 {
+			result["after"] = output.FormatTimestampRFC3339(rng.After)
+			result["before"] = output.FormatTimestampRFC3339(rng.Before)
+		}
+
+		return output.Write(os.Stdout, mode, result)
+	}
+
+	if len(rows) == 0 {
+		fmt.Fprintln(os.Stdout, "No conversations found.")
+
+		return nil
+	}
+
+	return output.WriteRows(os.Stdout, mode, cols, fields, rows)
+}
+
+// loadReportConversations returns the conversations active in rng with all
+// their messages, from Front or the local database.
+func loadReportConversations(ctx context.Context, flags *RootFlags, offline bool, rng report.Range) ([]report.Conversation, error) {
+	if offline {
+		db, _, err := openAccountDB(ctx, flags, true)
+		if err != nil {
+			return nil, err
+		}
+		defer db.Close()
+
+		convs, err := db.Conversations(ctx, store.ConversationFilter{ActiveAfter: rng.After, CreatedBefore: rng.Before})
+		if err != nil {
+			return nil, err
+		}
+
+		out := make([]report.Conversation, len(convs))
+
+		for i, conv := range convs {
+			msgs, err := db.Messages(ctx, conv.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			out[i] = report.Conversation{Conversation: conv, Messages: msgs}
+		}
+
+		return out, nil
+	}
+
+	client, err := getClient(flags)
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Set("q", fmt.Sprintf("after:%d before:%d", int64(rng.After), int64(rng.Before)))
+	params.Set("limit", "100")
+
+	var convs []api.Conversation
+
+	_, err = api.Paginate(ctx, client, "/conversations/search?"+params.Encode(), api.PageOptions{}, func(page []api.Conversation) error {
+		convs = append(convs, page...)
+
+		return nil
+	})
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return nil, err
+	}
+
+	out := make([]report.Conversation, len(convs))
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(4)
+
+	for i, conv := range convs {
+		g.Go(func() error {
+			var msgs []api.Message
+
+			_, err := api.Paginate(gctx, client, fmt.Sprintf("/conversations/%s/messages?limit=100", conv.ID), api.PageOptions{}, func(page []api.Message) error {
+				msgs = append(msgs, page...)
+
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+
+			out[i] = report.Conversation{Conversation: conv, Messages: msgs}
+
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return nil, err
+	}
+
+	return out, nil
+}
@@ -0,0 +1,215 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+	"github.com/dedene/frontapp-cli/internal/auth"
+)
+
+// resolver maps user-supplied references (an ID, a name, an email or "me")
+// to Front resource IDs. Values that already carry the resource's ID prefix
+// are returned as-is without any API call.
+type resolver struct {
+	client *api.Client
+	flags  *RootFlags
+}
+
+func newResolver(client *api.Client, flags *RootFlags) *resolver {
+	return &resolver{client: client, flags: flags}
+}
+
+// candidate is one resource a reference may match. keys are compared
+// case-insensitively against the reference.
+type candidate struct {
+	id    string
+	label string
+	keys  []string
+}
+
+func (r *resolver) tagID(ctx context.Context, ref string) (string, error) {
+	return r.resolve(ctx, "tag", ref, func(ctx context.Context) ([]candidate, error) {
+		resp, err := r.client.ListTags(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		out := make([]candidate, 0, len(resp.Results))
+		for _, t := range resp.Results {
+			out = append(out, candidate{id: t.ID, label: t.Name, keys: []string{t.Name}})
+		}
+
+		return out, nil
+	})
+}
+
+func (r *resolver) inboxID(ctx context.Context, ref string) (string, error) {
+	return r.resolve(ctx, "inbox", ref, func(ctx context.Context) ([]candidate, error) {
+		resp, err := r.client.ListInboxes(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		out := make([]candidate, 0, len(resp.Results))
+		for _, inbox := range resp.Results {
+			out = append(out, candidate{id: inbox.ID, label: inbox.Name, keys: []string{inbox.Name}})
+		}
+
+		return out, nil
+	})
+}
+
+func (r *resolver) channelID(ctx context.Context, ref string) (string, error) {
+	return r.resolve(ctx, "channel", ref, func(ctx context.Context) ([]candidate, error) {
+		resp, err := r.client.ListChannels(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		out := make([]candidate, 0, len(resp.Results))
+		for _, ch := range resp.Results {
+			label := ch.Name
+			if label == "" {
+				label = ch.Address
+			}
+
+			out = append(out, candidate{id: ch.ID, label: label, keys: []string{ch.Name, ch.Address, ch.SendAs}})
+		}
+
+		return out, nil
+	})
+}
+
+// teammateID resolves a teammate by ID, email, username, full name or "me".
+func (r *resolver) teammateID(ctx context.Context, ref string) (string, error) {
+	if strings.EqualFold(strings.TrimSpace(ref), "me") {
+		return r.meTeammateID(ctx)
+	}
+
+	return r.resolve(ctx, "teammate", ref, r.teammateCandidates)
+}
+
+func (r *resolver) teammateCandidates(ctx context.Context) ([]candidate, error) {
+	resp, err := r.client.ListTeammates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]candidate, 0, len(resp.Results))
+	for _, tm := range resp.Results {
+		name := strings.TrimSpace(tm.FirstName + " " + tm.LastName)
+		out = append(out, candidate{id: tm.ID, label: tm.Email, keys: []string{tm.Email, tm.Username, name}})
+	}
+
+	return out, nil
+}
+
+// meTeammateID finds the teammate behind the current credentials, first via
+// the email stored at login and then via /me.
+func (r *resolver) meTeammateID(ctx context.Context) (string, error) {
+	emails := make([]string, 0, 2)
+
+	if email, err := auth.GetAuthenticatedEmail(r.flags.Client); err == nil && email != "" {
+		emails = append(emails, email)
+	}
+
+	me, err := r.client.Me(ctx)
+	if err == nil {
+		if isResourceID("teammate", me.ID) {
+			return me.ID, nil
+		}
+
+		if me.Email != "" {
+			emails = append(emails, me.Email)
+		}
+	}
+
+	if len(emails) == 0 {
+		return "", fmt.Errorf("cannot resolve \"me\": no authenticated teammate email found")
+	}
+
+	candidates, err := r.teammateCandidates(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	for _, email := range emails {
+		for _, c := range candidates {
+			if strings.EqualFold(c.keys[0], email) {
+				return c.id, nil
+			}
+		}
+	}
+
+	return "", &ExitError{Code: api.ExitNotFound, Err: fmt.Errorf("cannot resolve \"me\": no teammate with email %s", emails[0])}
+}
+
+// isResourceID reports whether ref already looks like an ID of kind. Channel
+// IDs are issued with either the cha_ or the older chn_ prefix.
+func isResourceID(kind, ref string) bool {
+	prefix := api.ExtractPrefix(ref)
+	if kind == "channel" && prefix == "cha_" {
+		return true
+	}
+
+	return prefix != "" && api.ResourcePrefixes[prefix] == kind
+}
+
+// resolve returns ref unchanged when it is already an ID of kind, and
+// otherwise looks it up among the candidates returned by list.
+func (r *resolver) resolve(ctx context.Context, kind, ref string, list func(context.Context) ([]candidate, error)) (string, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" || isResourceID(kind, ref) {
+		return ref, nil
+	}
+
+	candidates, err := list(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	id, err := matchCandidate(kind, ref, candidates)
+	if err != nil {
+		return "", err
+	}
+
+	if r.flags.Verbose {
+		fmt.Fprintf(os.Stderr, "Resolved %s %q to %s\n", kind, ref, id)
+	}
+
+	return id, nil
+}
+
+func matchCandidate(kind, ref string, candidates []candidate) (string, error) {
+	var matches []candidate
+
+	for _, c := range candidates {
+		for _, key := range c.keys {
+			if key != "" && strings.EqualFold(key, ref) {
+				matches = append(matches, c)
+
+				break
+			}
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", &ExitError{Code: api.ExitNotFound, Err: fmt.Errorf("no %s matches %q", kind, ref)}
+	case 1:
+		return matches[0].id, nil
+	default:
+		options := make([]string, len(matches))
+		for i, m := range matches {
+			options[i] = fmt.Sprintf("%s (%s)", m.id, m.label)
+		}
+
+		return "", &ExitError{
+			Code: api.ExitUsage,
+			Err:  fmt.Errorf("%s %q is ambiguous, matches: %s; use an ID instead", kind, ref, strings.Join(options, ", ")),
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"golang.org/x/oauth2"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+)
+
+func TestConvTagResolvesTagName(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	var gotBody map[string][]string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/tags":
+			_, _ = io.WriteString(w, `{"_results":[{"id":"tag_1","name":"Billing"},{"id":"tag_2","name":"Urgent"}]}`)
+		case "/conversations/cnv_123/tags":
+			if err := json.NewDecoder(r.Body).Decode(&gotBody); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	old := newClientFromAuth
+	newClientFromAuth = func(_, _ string) (*api.Client, error) {
+		return api.NewClientWithBaseURL(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}), srv.URL), nil
+	}
+	t.Cleanup(func() { newClientFromAuth = old })
+
+	cmd := ConvTagCmd{IDs: []string{"cnv_123"}, Tag: "urgent"}
+	if err := cmd.Run(&RootFlags{Account: "test@example.com"}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if len(gotBody["tag_ids"]) != 1 || gotBody["tag_ids"][0] != "tag_2" {
+		t.Fatalf("unexpected body: %#v", gotBody)
+	}
+}
+
+func TestMatchCandidateAmbiguous(t *testing.T) {
+	candidates := []candidate{
+		{id: "tea_1", label: "a@example.com", keys: []string{"a@example.com", "alex"}},
+		{id: "tea_2", label: "b@example.com", keys: []string{"b@example.com", "Alex"}},
+	}
+
+	_, err := matchCandidate("teammate", "alex", candidates)
+	if err == nil || !strings.Contains(err.Error(), "ambiguous") || ExitCode(err) != api.ExitUsage {
+		t.Fatalf("expected ambiguity error, got %v", err)
+	}
+
+	id, err := matchCandidate("teammate", "B@example.com", candidates)
+	if err != nil || id != "tea_2" {
+		t.Fatalf("unexpected match %q, %v", id, err)
+	}
+}
+
+func TestDraftCreateResolvesChannel(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	var created bool
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/channels":
+			_, _ = io.WriteString(w, `{"_results":[{"id":"cha_1","name":"Support","address":"support@example.com"}]}`)
+		case "/channels/cha_1/drafts":
+			created = true
+			_, _ = io.WriteString(w, `{"id":"dra_1"}`)
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	old := newClientFromAuth
+	newClientFromAuth = func(_, _ string) (*api.Client, error) {
+		return api.NewClientWithBaseURL(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}), srv.URL), nil
+	}
+	t.Cleanup(func() { newClientFromAuth = old })
+
+	cmd := DraftCreateCmd{Channel: "support@example.com", To: "a@example.com", Body: "Hi"}
+	if err := cmd.Run(&RootFlags{Account: "test@example.com"}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if !created {
+		t.Fatal("draft was not created in the resolved channel")
+	}
+}
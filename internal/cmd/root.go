@@ -9,11 +9,19 @@ import (
 )
 
 type RootFlags struct {
-	Account string `help:"Account email for multi-account support"`
-	Client  string `help:"OAuth client name override"`
-	JSON    bool   `help:"Output JSON to stdout (best for scripting)"`
-	Plain   bool   `help:"Output TSV (stable for scripts)"`
-	Verbose bool   `help:"Enable verbose logging"`
+	Account      string   `help:"Account email for multi-account support"`
+	Client       string   `help:"OAuth client name override"`
+	JSON         bool     `help:"Output JSON to stdout (best for scripting)"`
+	NDJSON       bool     `help:"Output newline-delimited JSON, one object per result" name:"ndjson"`
+	YAML         bool     `help:"Output YAML" name:"yaml"`
+	Plain        bool     `help:"Output TSV (stable for scripts)"`
+	CSV          bool     `help:"Output CSV with a header row (RFC 4180)" name:"csv"`
+	JQ           string   `help:"Filter JSON output with a jq expression (implies --json)" name:"jq"`
+	Fields       []string `help:"Comma-separated columns to show in table output (e.g. id,subject,assignee)" sep:","`
+	Template     string   `help:"Render each result with a Go template (e.g. '{{.ID}} {{.Subject}}')"`
+	NoCache      bool     `help:"Bypass the local tag/inbox/teammate/channel cache" name:"no-cache"`
+	RefreshCache bool     `help:"Refetch cached tags, inboxes, teammates and channels" name:"refresh-cache"`
+	Verbose      bool     `help:"Enable verbose logging"`
 }
 
 type CLI struct {
@@ -33,6 +41,14 @@ type CLI struct {
 	Channel    ChannelCmd       `cmd:"" name:"channels" help:"Channels"`
 	Comment    CommentCmd       `cmd:"" name:"comments" help:"Comments (internal discussions)"`
 	Template   TemplateCmd      `cmd:"" name:"templates" help:"Templates (canned responses)"`
+	Cache      CacheCmd         `cmd:"" help:"Manage the local metadata cache"`
+	Tui        TuiCmd           `cmd:"" name:"tui" help:"Interactive inbox triage"`
+	Sync       SyncCmd          `cmd:"" help:"Mirror Front into a local SQLite database"`
+	Db         DbCmd            `cmd:"" name:"db" help:"Query the local database offline"`
+	Report     ReportCmd        `cmd:"" help:"Response time, volume and backlog reports"`
+	Analytics  AnalyticsCmd     `cmd:"" help:"Front analytics reports and exports"`
+	Mcp        McpCmd           `cmd:"" name:"mcp" help:"Model Context Protocol server for AI agents"`
+	Webhook    WebhookCmd       `cmd:"" help:"Receive Front webhooks"`
 	Completion CompletionCmd    `cmd:"" help:"Generate shell completions"`
 	Whoami     WhoamiCmd        `cmd:"" help:"Show authenticated user info"`
 }
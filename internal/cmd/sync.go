@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/dedene/frontapp-cli/internal/config"
+	"github.com/dedene/frontapp-cli/internal/errfmt"
+	"github.com/dedene/frontapp-cli/internal/output"
+	"github.com/dedene/frontapp-cli/internal/store"
+)
+
+type SyncCmd struct {
+	Full bool     `help:"Walk every conversation instead of stopping at the last sync"`
+	Only []string `help:"Only sync these resources (tags, inboxes, teammates, contacts, conversations)" sep:","`
+}
+
+func (c *SyncCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	db, path, err := openAccountDB(ctx, flags, false)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	opts := store.SyncOptions{Resources: c.Only, Full: c.Full}
+	if !mode.JSON {
+		opts.Progress = func(s store.Stats) {
+			fmt.Fprintf(os.Stderr, "Synced %d conversation(s), %d message(s), %d comment(s)...\n", s.Conversations, s.Messages, s.Comments)
+		}
+	}
+
+	stats, err := store.Sync(ctx, client, db, opts)
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		if stats.Conversations > 0 {
+			fmt.Fprintln(os.Stderr, "Progress was saved; run 'frontcli sync' again to resume.")
+		}
+
+		return err
+	}
+
+	if mode.JSON {
+		return output.Write(os.Stdout, mode, map[string]any{"database": path, "stats": stats})
+	}
+
+	kind := "Full sync"
+	if stats.Incremental {
+		kind = "Incremental sync"
+	}
+
+	if stats.Resumed {
+		kind += " (resumed)"
+	}
+
+	fmt.Fprintf(os.Stdout, "%s complete: %d conversation(s), %d message(s), %d comment(s), %d contact(s), %d tag(s), %d inbox(es), %d teammate(s)\n",
+		kind, stats.Conversations, stats.Messages, stats.Comments, stats.Contacts, stats.Tags, stats.Inboxes, stats.Teammates)
+	fmt.Fprintf(os.Stdout, "Database: %s\n", path)
+
+	return nil
+}
+
+type DbCmd struct {
+	Query DbQueryCmd `cmd:"" help:"Run a read-only SQL query against the synced database"`
+}
+
+type DbQueryCmd struct {
+	SQL string `arg:"" help:"SQL statement (e.g. \"SELECT id, subject FROM conversations LIMIT 10\")"`
+}
+
+func (c *DbQueryCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	db, _, err := openAccountDB(ctx, flags, true)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	res, err := db.Query(ctx, c.SQL)
+	if err != nil {
+		return err
+	}
+
+	if mode.JSON {
+		rows := make([]map[string]any, 0, len(res.Rows))
+
+		for _, row := range res.Rows {
+			m := make(map[string]any, len(res.Columns))
+			for i, col := range res.Columns {
+				m[col] = row[i]
+			}
+
+			rows = append(rows, m)
+		}
+
+		if mode.NDJSON {
+			return output.Write(os.Stdout, mode, rows)
+		}
+
+		return output.Write(os.Stdout, mode, map[string]any{"columns": res.Columns, "rows": rows})
+	}
+
+	if len(res.Columns) == 0 {
+		return nil
+	}
+
+	tbl := output.NewModeTableWriter(os.Stdout, mode)
+	tbl.AddRow(res.Columns...)
+
+	for _, row := range res.Rows {
+		cells := make([]string, len(row))
+		for i, v := range row {
+			cells[i] = formatDBValue(v)
+		}
+
+		tbl.AddRow(cells...)
+	}
+
+	return tbl.Flush()
+}
+
+func formatDBValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// openAccountDB opens the local database for the active account.
+func openAccountDB(ctx context.Context, flags *RootFlags, readOnly bool) (*store.DB, string, error) {
+	_, email, err := resolveAccount(flags)
+	if err != nil {
+		return nil, "", err
+	}
+
+	dir, err := config.DatabaseDir()
+	if err != nil {
+		return nil, "", err
+	}
+
+	path := store.Path(dir, email)
+
+	open := store.Open
+	if readOnly {
+		open = store.OpenReadOnly
+	}
+
+	db, err := open(ctx, path)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return db, path, nil
+}
@@ -47,7 +47,7 @@ func (c *TagListCmd) Run(flags *RootFlags) error {
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, resp)
+		return output.Write(os.Stdout, mode, resp)
 	}
 
 	if len(resp.Results) == 0 {
@@ -60,14 +60,7 @@ func (c *TagListCmd) Run(flags *RootFlags) error {
 		return renderTagTree(resp.Results)
 	}
 
-	tbl := output.NewTableWriter(os.Stdout, mode.Plain)
-	tbl.AddRow("ID", "NAME", "COLOR")
-
-	for _, tag := range resp.Results {
-		tbl.AddRow(output.FormatTag(tag)...)
-	}
-
-	return tbl.Flush()
+	return output.WriteRows(os.Stdout, mode, output.TagColumns, output.TagFields, resp.Results)
 }
 
 type TagGetCmd struct {
@@ -95,7 +88,11 @@ func (c *TagGetCmd) Run(flags *RootFlags) error {
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, tag)
+		return output.Write(os.Stdout, mode, tag)
+	}
+
+	if mode.Columnar() {
+		return output.WriteItem(os.Stdout, mode, output.TagColumns, *tag)
 	}
 
 	fmt.Fprintf(os.Stdout, "ID:          %s\n", tag.ID)
@@ -158,7 +155,7 @@ func (c *TagCreateCmd) Run(flags *RootFlags) error {
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, result)
+		return output.Write(os.Stdout, mode, result)
 	}
 
 	fmt.Fprintf(os.Stdout, "Tag created: %s (%s)\n", result.Name, result.ID)
@@ -212,7 +209,7 @@ func (c *TagUpdateCmd) Run(flags *RootFlags) error {
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, result)
+		return output.Write(os.Stdout, mode, result)
 	}
 
 	fmt.Fprintf(os.Stdout, "Tag updated: %s\n", result.Name)
@@ -268,7 +265,7 @@ func (c *TagChildrenCmd) Run(flags *RootFlags) error {
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, resp)
+		return output.Write(os.Stdout, mode, resp)
 	}
 
 	if len(resp.Results) == 0 {
@@ -277,19 +274,14 @@ func (c *TagChildrenCmd) Run(flags *RootFlags) error {
 		return nil
 	}
 
-	tbl := output.NewTableWriter(os.Stdout, mode.Plain)
-	tbl.AddRow("ID", "NAME", "COLOR")
-
-	for _, tag := range resp.Results {
-		tbl.AddRow(output.FormatTag(tag)...)
-	}
-
-	return tbl.Flush()
+	return output.WriteRows(os.Stdout, mode, output.TagColumns, output.TagFields, resp.Results)
 }
 
 type TagConvosCmd struct {
 	ID    string `arg:"" help:"Tag ID"`
 	Limit int    `help:"Maximum number of results" default:"25"`
+
+	PaginationFlags `embed:""`
 }
 
 func (c *TagConvosCmd) Run(flags *RootFlags) error {
@@ -306,15 +298,15 @@ func (c *TagConvosCmd) Run(flags *RootFlags) error {
 	}
 
 	path := fmt.Sprintf("/tags/%s/conversations?limit=%d", c.ID, c.Limit)
-	var resp api.ListResponse[api.Conversation]
-	if err := client.Get(ctx, path, &resp); err != nil {
+	resp, err := fetchList[api.Conversation](ctx, client, path, c.PaginationFlags, mode)
+	if err != nil {
 		fmt.Fprint(os.Stderr, errfmt.Format(err))
 
 		return err
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, resp)
+		return output.Write(os.Stdout, mode, resp)
 	}
 
 	if len(resp.Results) == 0 {
@@ -323,14 +315,13 @@ func (c *TagConvosCmd) Run(flags *RootFlags) error {
 		return nil
 	}
 
-	tbl := output.NewTableWriter(os.Stdout, mode.Plain)
-	tbl.AddRow("ID", "STATUS", "ASSIGNEE", "SUBJECT", "CREATED")
-
-	for _, conv := range resp.Results {
-		tbl.AddRow(output.FormatConversation(conv)...)
+	if err := output.WriteRows(os.Stdout, mode, output.ConversationColumns, output.ConversationFields, resp.Results); err != nil {
+		return err
 	}
 
-	return tbl.Flush()
+	printMoreResultsHint(resp.Pagination.Next)
+
+	return nil
 }
 
 func renderTagTree(tags []api.Tag) error {
@@ -39,7 +39,7 @@ func (c *TeammateListCmd) Run(flags *RootFlags) error {
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, resp)
+		return output.Write(os.Stdout, mode, resp)
 	}
 
 	if len(resp.Results) == 0 {
@@ -48,14 +48,7 @@ func (c *TeammateListCmd) Run(flags *RootFlags) error {
 		return nil
 	}
 
-	tbl := output.NewTableWriter(os.Stdout, mode.Plain)
-	tbl.AddRow("ID", "EMAIL", "NAME")
-
-	for _, tm := range resp.Results {
-		tbl.AddRow(output.FormatTeammate(tm)...)
-	}
-
-	return tbl.Flush()
+	return output.WriteRows(os.Stdout, mode, output.TeammateColumns, output.TeammateFields, resp.Results)
 }
 
 type TeammateGetCmd struct {
@@ -83,7 +76,11 @@ func (c *TeammateGetCmd) Run(flags *RootFlags) error {
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, tm)
+		return output.Write(os.Stdout, mode, tm)
+	}
+
+	if mode.Columnar() {
+		return output.WriteItem(os.Stdout, mode, output.TeammateColumns, *tm)
 	}
 
 	fmt.Fprintf(os.Stdout, "ID:        %s\n", tm.ID)
@@ -99,6 +96,8 @@ func (c *TeammateGetCmd) Run(flags *RootFlags) error {
 type TeammateConvosCmd struct {
 	ID    string `arg:"" help:"Teammate ID"`
 	Limit int    `help:"Maximum number of results" default:"25"`
+
+	PaginationFlags `embed:""`
 }
 
 func (c *TeammateConvosCmd) Run(flags *RootFlags) error {
@@ -115,15 +114,15 @@ func (c *TeammateConvosCmd) Run(flags *RootFlags) error {
 	}
 
 	path := fmt.Sprintf("/teammates/%s/conversations?limit=%d", c.ID, c.Limit)
-	var resp api.ListResponse[api.Conversation]
-	if err := client.Get(ctx, path, &resp); err != nil {
+	resp, err := fetchList[api.Conversation](ctx, client, path, c.PaginationFlags, mode)
+	if err != nil {
 		fmt.Fprint(os.Stderr, errfmt.Format(err))
 
 		return err
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, resp)
+		return output.Write(os.Stdout, mode, resp)
 	}
 
 	if len(resp.Results) == 0 {
@@ -132,12 +131,11 @@ func (c *TeammateConvosCmd) Run(flags *RootFlags) error {
 		return nil
 	}
 
-	tbl := output.NewTableWriter(os.Stdout, mode.Plain)
-	tbl.AddRow("ID", "STATUS", "ASSIGNEE", "SUBJECT", "CREATED")
-
-	for _, conv := range resp.Results {
-		tbl.AddRow(output.FormatConversation(conv)...)
+	if err := output.WriteRows(os.Stdout, mode, output.ConversationColumns, output.ConversationFields, resp.Results); err != nil {
+		return err
 	}
 
-	return tbl.Flush()
+	printMoreResultsHint(resp.Pagination.Next)
+
+	return nil
 }
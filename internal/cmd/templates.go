@@ -7,6 +7,7 @@ import (
 
 	"github.com/dedene/frontapp-cli/internal/api"
 	"github.com/dedene/frontapp-cli/internal/errfmt"
+	"github.com/dedene/frontapp-cli/internal/markdown"
 	"github.com/dedene/frontapp-cli/internal/output"
 )
 
@@ -39,7 +40,7 @@ func (c *TemplateListCmd) Run(flags *RootFlags) error {
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, resp)
+		return output.Write(os.Stdout, mode, resp)
 	}
 
 	if len(resp.Results) == 0 {
@@ -48,18 +49,7 @@ func (c *TemplateListCmd) Run(flags *RootFlags) error {
 		return nil
 	}
 
-	tbl := output.NewTableWriter(os.Stdout, mode.Plain)
-	tbl.AddRow("ID", "NAME", "SUBJECT")
-
-	for _, tmpl := range resp.Results {
-		tbl.AddRow(
-			tmpl.ID,
-			tmpl.Name,
-			tmpl.Subject,
-		)
-	}
-
-	return tbl.Flush()
+	return output.WriteRows(os.Stdout, mode, output.TemplateColumns, output.TemplateFields, resp.Results)
 }
 
 type TemplateGetCmd struct {
@@ -87,7 +77,11 @@ func (c *TemplateGetCmd) Run(flags *RootFlags) error {
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, tmpl)
+		return output.Write(os.Stdout, mode, tmpl)
+	}
+
+	if mode.Columnar() {
+		return output.WriteItem(os.Stdout, mode, output.TemplateColumns, tmpl)
 	}
 
 	fmt.Fprintf(os.Stdout, "ID:      %s\n", tmpl.ID)
@@ -104,7 +98,8 @@ func (c *TemplateGetCmd) Run(flags *RootFlags) error {
 }
 
 type TemplateUseCmd struct {
-	ID string `arg:"" help:"Template ID"`
+	ID      string `arg:"" help:"Template ID"`
+	PrintAs string `help:"Print the body as html (as stored), markdown or text" name:"print-as" enum:"markdown,html,text" default:"html"`
 }
 
 func (c *TemplateUseCmd) Run(flags *RootFlags) error {
@@ -122,7 +117,12 @@ func (c *TemplateUseCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
-	fmt.Fprintln(os.Stdout, tmpl.Body)
+	body, err := markdown.Convert(tmpl.Body, c.PrintAs)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(os.Stdout, body)
 
 	return nil
 }
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+	"github.com/dedene/frontapp-cli/internal/tui"
+)
+
+type TuiCmd struct {
+	Inbox  string `help:"Show an inbox (ID or name)"`
+	Tag    string `help:"Show a tag (ID or name)"`
+	Status string `help:"Filter by status (open, assigned, unassigned, archived, snoozed, trashed)" default:"open"`
+	Limit  int    `help:"Maximum number of conversations listed" default:"50"`
+}
+
+func (c *TuiCmd) Run(flags *RootFlags) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	res := newResolver(client, flags)
+
+	inboxID, err := res.inboxID(ctx, c.Inbox)
+	if err != nil {
+		return err
+	}
+
+	tagID, err := res.tagID(ctx, c.Tag)
+	if err != nil {
+		return err
+	}
+
+	opts := api.ListConversationsOptions{
+		InboxID:  inboxID,
+		TagID:    tagID,
+		Statuses: api.ParseStatus(c.Status),
+		Limit:    c.Limit,
+	}
+
+	// Resolution messages would corrupt the full-screen view.
+	quiet := *flags
+	quiet.Verbose = false
+	res = newResolver(client, &quiet)
+
+	return tui.Run(ctx, tui.Options{
+		Client:   client,
+		ListPath: "/conversations?" + opts.Query(),
+		Timeline: func(ctx context.Context, convID string) (string, error) {
+			var buf bytes.Buffer
+
+			err := (&ConvGetCmd{ID: convID}).writeFullTimeline(ctx, client, &buf)
+
+			return buf.String(), err
+		},
+		ResolveTeammate: res.teammateID,
+		ResolveTag:      res.tagID,
+	})
+}
@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/dedene/frontapp-cli/internal/config"
+	"github.com/dedene/frontapp-cli/internal/output"
+	"github.com/dedene/frontapp-cli/internal/webhook"
+)
+
+type WebhookCmd struct {
+	Listen WebhookListenCmd `cmd:"" help:"Receive Front webhooks and print or dispatch their events"`
+}
+
+type WebhookListenCmd struct {
+	Port           int               `help:"Port to listen on" default:"8080"`
+	Host           string            `help:"Interface to listen on (0.0.0.0 accepts outside connections)" default:"127.0.0.1"`
+	Path           string            `help:"URL path webhooks are posted to" default:"/"`
+	Secret         string            `help:"App secret (application webhooks) or API secret (rule webhooks) used to verify signatures" env:"FRONT_WEBHOOK_SECRET" required:""`
+	On             map[string]string `help:"Run a shell command for an event type instead of printing it, e.g. --on inbound='./notify.sh' ('*' matches any type; repeatable)" mapsep:"none"`
+	CommandTimeout time.Duration     `help:"Kill an event command after this long" name:"command-timeout" default:"30s"`
+	RuleWebhook    bool              `help:"Verify rule webhooks (HMAC-SHA1 with the API secret) instead of application webhooks" name:"rule-webhook"`
+}
+
+func (c *WebhookListenCmd) Run(flags *RootFlags) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	// Events are a stream, so they are always printed one per line.
+	mode.JSON, mode.NDJSON, mode.YAML = true, true, false
+
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return err
+	}
+
+	commands := make(map[string]string, len(cfg.WebhookCommands)+len(c.On))
+	for k, v := range cfg.WebhookCommands {
+		commands[k] = v
+	}
+
+	for k, v := range c.On {
+		commands[k] = v
+	}
+
+	scheme := webhook.SchemeApp
+	if c.RuleWebhook {
+		scheme = webhook.SchemeRule
+	}
+
+	d := &webhookDispatcher{commands: commands, timeout: c.CommandTimeout, mode: mode, verbose: flags.Verbose}
+
+	mux := http.NewServeMux()
+	mux.Handle(c.Path, &webhook.Handler{
+		Verifier: webhook.Verifier{Secret: c.Secret, Scheme: scheme},
+		OnEvent:  d.dispatch,
+		OnError: func(r *http.Request, err error) {
+			fmt.Fprintf(os.Stderr, "Rejected webhook from %s: %v\n", r.RemoteAddr, err)
+		},
+	})
+
+	ln, err := net.Listen("tcp", net.JoinHostPort(c.Host, strconv.Itoa(c.Port)))
+	if err != nil {
+		return fmt.Errorf("listen: %w", err)
+	}
+
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), c.CommandTimeout+5*time.Second)
+		defer cancel()
+
+		_ = srv.Shutdown(shutdownCtx) //nolint:contextcheck // the signal context is already done
+	}()
+
+	fmt.Fprintf(os.Stderr, "Listening for Front webhooks on http://%s%s\n", ln.Addr(), c.Path)
+
+	if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("serve: %w", err)
+	}
+
+	return nil
+}
+
+// webhookDispatcher prints events as NDJSON or runs the command configured
+// for their type.
+type webhookDispatcher struct {
+	commands map[string]string
+	timeout  time.Duration
+	mode     output.Mode
+	verbose  bool
+
+	mu sync.Mutex // serializes stdout
+}
+
+func (d *webhookDispatcher) command(eventType string) string {
+	if cmd, ok := d.commands[eventType]; ok {
+		return cmd
+	}
+
+	return d.commands["*"]
+}
+
+func (d *webhookDispatcher) dispatch(ctx context.Context, ev *webhook.Event) error {
+	command := d.command(ev.Type)
+	if command == "" {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+
+		return output.Write(os.Stdout, d.mode, ev)
+	}
+
+	return d.run(ctx, command, ev)
+}
+
+// run executes command with the event as JSON on stdin and its type, ID and
+// conversation ID in FRONT_EVENT_TYPE, FRONT_EVENT_ID and
+// FRONT_CONVERSATION_ID. The command's output goes to stderr so stdout stays
+// a clean event stream.
+func (d *webhookDispatcher) run(ctx context.Context, command string, ev *webhook.Event) error {
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return fmt.Errorf("encode event: %w", err)
+	}
+
+	// Finish the command even if Front hangs up first.
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), d.timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", command) //nolint:gosec // the command is configured by the user
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(),
+		"FRONT_EVENT_TYPE="+ev.Type,
+		"FRONT_EVENT_ID="+ev.ID,
+		"FRONT_CONVERSATION_ID="+ev.ConversationID(),
+	)
+
+	if d.verbose {
+		fmt.Fprintf(os.Stderr, "Running %q for %s event %s\n", command, ev.Type, ev.ID)
+	}
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s command for event %s: %w", ev.Type, ev.ID, err)
+	}
+
+	return nil
+}
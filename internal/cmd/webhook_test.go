@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+	"github.com/dedene/frontapp-cli/internal/webhook"
+)
+
+func TestWebhookDispatcherRunsCommand(t *testing.T) {
+	dir := t.TempDir()
+	out := filepath.Join(dir, "event.json")
+
+	d := &webhookDispatcher{
+		commands: map[string]string{
+			webhook.EventInbound: `cat > "$OUT" && echo "$FRONT_EVENT_TYPE $FRONT_EVENT_ID $FRONT_CONVERSATION_ID" >> "$OUT.env"`,
+			"*":                  "exit 3",
+		},
+		timeout: 5 * time.Second,
+	}
+
+	t.Setenv("OUT", out)
+
+	ev := &webhook.Event{ID: "evt_1", Type: webhook.EventInbound, Conversation: &api.Conversation{ID: "cnv_1"}}
+	if err := d.dispatch(context.Background(), ev); err != nil {
+		t.Fatalf("dispatch: %v", err)
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got webhook.Event
+	if err := json.Unmarshal(data, &got); err != nil || got.ID != "evt_1" {
+		t.Fatalf("unexpected stdin %s: %v", data, err)
+	}
+
+	env, err := os.ReadFile(out + ".env")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.TrimSpace(string(env)) != "inbound evt_1 cnv_1" {
+		t.Fatalf("unexpected env %q", env)
+	}
+
+	// Other types fall back to "*", whose failure is reported so Front retries.
+	if err := d.dispatch(context.Background(), &webhook.Event{ID: "evt_2", Type: webhook.EventTag}); err == nil {
+		t.Fatal("expected the failing command to return an error")
+	}
+}
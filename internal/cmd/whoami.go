@@ -80,7 +80,7 @@ func (c *WhoamiCmd) Run(flags *RootFlags) error {
 			result["teammate"] = teammate
 		}
 
-		return output.WriteJSON(os.Stdout, result)
+		return output.Write(os.Stdout, mode, result)
 	}
 
 	// Show account info
@@ -13,6 +13,13 @@ type File struct {
 	AccountDomains map[string]string `yaml:"account_domains,omitempty"`
 	DefaultOutput  string            `yaml:"default_output,omitempty"`
 	Timezone       string            `yaml:"timezone,omitempty"`
+	CacheTTL       string            `yaml:"cache_ttl,omitempty"`
+	// SharedRateLimit makes parallel invocations for an account pace
+	// themselves through a shared rate limit state file.
+	SharedRateLimit bool `yaml:"shared_rate_limit,omitempty"`
+	// WebhookCommands maps webhook event types ("*" for any) to shell
+	// commands run by 'webhook listen'.
+	WebhookCommands map[string]string `yaml:"webhook_commands,omitempty"`
 }
 
 func ConfigExists() (bool, error) {
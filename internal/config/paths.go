@@ -106,6 +106,46 @@ func EnsureAttachmentsDir() (string, error) {
 	return dir, nil
 }
 
+func CacheDir() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "cache"), nil
+}
+
+func LocksDir() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "locks"), nil
+}
+
+func EnsureLocksDir() (string, error) {
+	dir, err := LocksDir()
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("ensure locks dir: %w", err)
+	}
+
+	return dir, nil
+}
+
+func DatabaseDir() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "db"), nil
+}
+
 // ExpandPath expands ~ at the beginning of a path to the user's home directory.
 func ExpandPath(path string) (string, error) {
 	if path == "" {
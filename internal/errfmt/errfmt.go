@@ -133,7 +133,8 @@ func formatNotAuthenticatedError() string {
 	sb.WriteString("Error: Not authenticated\n\n")
 	sb.WriteString("  Run 'frontcli auth login' to authenticate with Front.\n\n")
 	sb.WriteString("  If you need to set up OAuth credentials first:\n")
-	sb.WriteString("    frontcli auth setup <client_id>\n")
+	sb.WriteString("    frontcli auth setup <client_id>\n\n")
+	sb.WriteString("  Or, for CI and service accounts, set FRONT_API_TOKEN.\n")
 
 	return sb.String()
 }
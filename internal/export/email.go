@@ -0,0 +1,296 @@
+// Package export writes Front messages as RFC 5322 (.eml) files and mbox
+// archives.
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/textproto"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Email is a single message to export.
+type Email struct {
+	From      string
+	To        []string
+	Cc        []string
+	Bcc       []string
+	Subject   string
+	Date      time.Time
+	MessageID string
+
+	// Headers are extra headers, e.g. X-Front-Conversation-Id.
+	Headers map[string]string
+
+	Text string
+	HTML string
+
+	Attachments []Attachment
+}
+
+// Attachment is a file embedded as a MIME part.
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// WriteEML writes e as an RFC 5322 message with CRLF line endings.
+func WriteEML(w io.Writer, e Email) error {
+	var buf bytes.Buffer
+
+	writeHeaders(&buf, e)
+
+	if err := writeBody(&buf, e); err != nil {
+		return err
+	}
+
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("write message: %w", err)
+	}
+
+	return nil
+}
+
+func writeHeaders(buf *bytes.Buffer, e Email) {
+	header := func(name, value string) {
+		if value != "" {
+			fmt.Fprintf(buf, "%s: %s\r\n", name, value)
+		}
+	}
+
+	header("From", encodeAddress(e.From))
+	header("To", encodeAddresses(e.To))
+	header("Cc", encodeAddresses(e.Cc))
+	header("Bcc", encodeAddresses(e.Bcc))
+	header("Subject", mime.QEncoding.Encode("utf-8", e.Subject))
+
+	if !e.Date.IsZero() {
+		header("Date", e.Date.Format(time.RFC1123Z))
+	}
+
+	if e.MessageID != "" {
+		header("Message-ID", "<"+e.MessageID+">")
+	}
+
+	names := make([]string, 0, len(e.Headers))
+	for name := range e.Headers {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	for _, name := range names {
+		header(textproto.CanonicalMIMEHeaderKey(name), mime.QEncoding.Encode("utf-8", e.Headers[name]))
+	}
+
+	header("MIME-Version", "1.0")
+}
+
+// part is a MIME entity: its headers and encoded body.
+type part struct {
+	header textproto.MIMEHeader
+	body   []byte
+}
+
+func writeBody(buf *bytes.Buffer, e Email) error {
+	content, err := contentPart(e)
+	if err != nil {
+		return err
+	}
+
+	if len(e.Attachments) > 0 {
+		parts := []part{content}
+
+		for _, a := range e.Attachments {
+			parts = append(parts, attachmentPart(a))
+		}
+
+		content, err = multipartPart("multipart/mixed", parts)
+		if err != nil {
+			return err
+		}
+	}
+
+	keys := make([]string, 0, len(content.header))
+	for k := range content.header {
+		keys = append(keys, k)
+	}
+
+	slices.Sort(keys)
+
+	for _, k := range keys {
+		fmt.Fprintf(buf, "%s: %s\r\n", k, content.header.Get(k))
+	}
+
+	buf.WriteString("\r\n")
+	buf.Write(content.body)
+
+	return nil
+}
+
+// contentPart is the text body, with an HTML alternative when there is one.
+func contentPart(e Email) (part, error) {
+	text, err := textPart("text/plain", e.Text)
+	if err != nil || e.HTML == "" {
+		return text, err
+	}
+
+	html, err := textPart("text/html", e.HTML)
+	if err != nil {
+		return part{}, err
+	}
+
+	return multipartPart("multipart/alternative", []part{text, html})
+}
+
+func textPart(contentType, text string) (part, error) {
+	var body bytes.Buffer
+
+	qp := quotedprintable.NewWriter(&body)
+	if _, err := qp.Write([]byte(toCRLF(text))); err != nil {
+		return part{}, fmt.Errorf("encode text: %w", err)
+	}
+
+	if err := qp.Close(); err != nil {
+		return part{}, fmt.Errorf("encode text: %w", err)
+	}
+
+	body.WriteString("\r\n")
+
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Type", contentType+"; charset=utf-8")
+	h.Set("Content-Transfer-Encoding", "quoted-printable")
+
+	return part{header: h, body: body.Bytes()}, nil
+}
+
+func multipartPart(contentType string, parts []part) (part, error) {
+	var body bytes.Buffer
+
+	mw := multipart.NewWriter(&body)
+
+	for _, p := range parts {
+		w, err := mw.CreatePart(p.header)
+		if err != nil {
+			return part{}, fmt.Errorf("create part: %w", err)
+		}
+
+		if _, err := w.Write(p.body); err != nil {
+			return part{}, fmt.Errorf("write part: %w", err)
+		}
+	}
+
+	if err := mw.Close(); err != nil {
+		return part{}, fmt.Errorf("close multipart: %w", err)
+	}
+
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Type", mime.FormatMediaType(contentType, map[string]string{"boundary": mw.Boundary()}))
+
+	return part{header: h, body: body.Bytes()}, nil
+}
+
+func attachmentPart(a Attachment) part {
+	contentType := a.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Type", contentType)
+	h.Set("Content-Transfer-Encoding", "base64")
+	h.Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": a.Filename}))
+
+	var body bytes.Buffer
+
+	encoded := base64.StdEncoding.EncodeToString(a.Data)
+	for len(encoded) > 76 {
+		body.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+
+	body.WriteString(encoded + "\r\n")
+
+	return part{header: h, body: body.Bytes()}
+}
+
+func encodeAddresses(addrs []string) string {
+	out := make([]string, 0, len(addrs))
+	for _, a := range addrs {
+		if a = encodeAddress(a); a != "" {
+			out = append(out, a)
+		}
+	}
+
+	return strings.Join(out, ", ")
+}
+
+// encodeAddress keeps plain addresses as-is and Q-encodes anything else
+// (phone numbers, chat handles) so the header stays ASCII.
+func encodeAddress(addr string) string {
+	return mime.QEncoding.Encode("utf-8", strings.TrimSpace(addr))
+}
+
+func toCRLF(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "\r\n", "\n"), "\n", "\r\n")
+}
+
+// fromLine matches lines that mboxrd quotes with an extra '>'.
+var fromLine = regexp.MustCompile(`^>*From `)
+
+// Mbox appends messages to an mbox (mboxrd) archive.
+type Mbox struct {
+	w io.Writer
+}
+
+// NewMbox returns an Mbox writing to w.
+func NewMbox(w io.Writer) *Mbox {
+	return &Mbox{w: w}
+}
+
+// Add appends e to the archive.
+func (m *Mbox) Add(e Email) error {
+	var msg bytes.Buffer
+	if err := WriteEML(&msg, e); err != nil {
+		return err
+	}
+
+	sender := e.From
+	if sender == "" || strings.ContainsAny(sender, " \t") {
+		sender = "MAILER-DAEMON"
+	}
+
+	date := e.Date
+	if date.IsZero() {
+		date = time.Unix(0, 0)
+	}
+
+	bw := bufio.NewWriter(m.w)
+	fmt.Fprintf(bw, "From %s %s\n", sender, date.UTC().Format(time.ANSIC))
+
+	for _, line := range strings.Split(strings.TrimRight(strings.ReplaceAll(msg.String(), "\r\n", "\n"), "\n"), "\n") {
+		if fromLine.MatchString(line) {
+			line = ">" + line
+		}
+
+		bw.WriteString(line + "\n")
+	}
+
+	bw.WriteString("\n")
+
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("write mbox: %w", err)
+	}
+
+	return nil
+}
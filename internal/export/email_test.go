@@ -0,0 +1,98 @@
+package export
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteEMLWithAttachment(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := WriteEML(&buf, Email{
+		From:      "customer@example.com",
+		To:        []string{"support@example.com"},
+		Subject:   "Invoice – März",
+		Date:      time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC),
+		MessageID: "msg_1@frontapp.com",
+		Headers:   map[string]string{"X-Front-Conversation-Id": "cnv_1"},
+		Text:      "See attached.\nThanks",
+		HTML:      "<p>See attached.</p>",
+		Attachments: []Attachment{
+			{Filename: "invoice.pdf", ContentType: "application/pdf", Data: []byte("%PDF-1.4 data")},
+		},
+	})
+	if err != nil {
+		t.Fatalf("WriteEML: %v", err)
+	}
+
+	msg, err := mail.ReadMessage(&buf)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	subject, _ := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if subject != "Invoice – März" {
+		t.Errorf("subject = %q", subject)
+	}
+
+	if msg.Header.Get("X-Front-Conversation-Id") != "cnv_1" || msg.Header.Get("Message-Id") != "<msg_1@frontapp.com>" {
+		t.Errorf("headers = %v", msg.Header)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/mixed" {
+		t.Fatalf("content type = %q, %v", mediaType, err)
+	}
+
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+
+	first, err := mr.NextPart()
+	if err != nil || !strings.HasPrefix(first.Header.Get("Content-Type"), "multipart/alternative") {
+		t.Fatalf("first part = %v, %v", first.Header, err)
+	}
+
+	att, err := mr.NextRawPart()
+	if err != nil {
+		t.Fatalf("attachment part: %v", err)
+	}
+
+	if att.FileName() != "invoice.pdf" || att.Header.Get("Content-Transfer-Encoding") != "base64" {
+		t.Errorf("attachment header = %v", att.Header)
+	}
+
+	raw, _ := io.ReadAll(att)
+	if !strings.Contains(string(raw), "JVBERi0xLjQgZGF0YQ==") {
+		t.Errorf("attachment body = %q", raw)
+	}
+}
+
+func TestMboxQuotesFromLines(t *testing.T) {
+	var buf bytes.Buffer
+
+	mbox := NewMbox(&buf)
+	for _, text := range []string{"From the start", "hello\n>From quoted"} {
+		if err := mbox.Add(Email{From: "a@example.com", Date: time.Unix(0, 0), Text: text}); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+
+	out := buf.String()
+
+	if strings.Count(out, "\nFrom a@example.com ") != 1 || !strings.HasPrefix(out, "From a@example.com Thu Jan  1 00:00:00 1970\n") {
+		t.Errorf("separator lines wrong:\n%s", out)
+	}
+
+	if !strings.Contains(out, "\n>From the start") || !strings.Contains(out, "\n>>From quoted") {
+		t.Errorf("From lines not quoted:\n%s", out)
+	}
+
+	if strings.Contains(out, "\r") {
+		t.Error("mbox should use LF line endings")
+	}
+}
@@ -0,0 +1,182 @@
+package markdown
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+	"unicode"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	gmhtml "github.com/yuin/goldmark/renderer/html"
+	xhtml "golang.org/x/net/html"
+)
+
+// Body formats understood by Render and Convert.
+const (
+	FormatMarkdown = "markdown"
+	FormatHTML     = "html"
+	FormatText     = "text"
+)
+
+// ErrUnknownFormat is returned for a body format other than the ones above.
+var ErrUnknownFormat = errors.New("unknown body format (use markdown, html or text)")
+
+var (
+	renderer = goldmark.New(
+		goldmark.WithExtensions(extension.GFM),
+		// Inline HTML is passed through here and cleaned by the sanitizer.
+		goldmark.WithRendererOptions(gmhtml.WithUnsafe(), gmhtml.WithHardWraps()),
+	)
+	sanitizer = bluemonday.UGCPolicy()
+)
+
+// ToHTML renders CommonMark (with GitHub tables, strikethrough and autolinks)
+// to sanitized HTML.
+func ToHTML(input string) (string, error) {
+	if strings.TrimSpace(input) == "" {
+		return "", nil
+	}
+
+	var buf bytes.Buffer
+	if err := renderer.Convert([]byte(input), &buf); err != nil {
+		return "", fmt.Errorf("render markdown: %w", err)
+	}
+
+	return strings.TrimSpace(sanitizer.Sanitize(buf.String())), nil
+}
+
+// TextToHTML escapes plain text and keeps its paragraphs and line breaks.
+func TextToHTML(input string) string {
+	input = strings.TrimSpace(strings.ReplaceAll(input, "\r\n", "\n"))
+	if input == "" {
+		return ""
+	}
+
+	paragraphs := strings.Split(input, "\n\n")
+	for i, p := range paragraphs {
+		lines := strings.Split(strings.Trim(p, "\n"), "\n")
+		for j, line := range lines {
+			lines[j] = html.EscapeString(line)
+		}
+
+		paragraphs[i] = "<p>" + strings.Join(lines, "<br>") + "</p>"
+	}
+
+	return strings.Join(paragraphs, "\n")
+}
+
+// ToText extracts the readable text of an HTML body, one line per block.
+func ToText(input string) (string, error) {
+	var b strings.Builder
+
+	z := xhtml.NewTokenizer(strings.NewReader(input))
+
+	for {
+		switch z.Next() {
+		case xhtml.ErrorToken:
+			if err := z.Err(); err != io.EOF {
+				return "", fmt.Errorf("parse html: %w", err)
+			}
+
+			return strings.TrimSpace(collapseBlankLines(b.String())), nil
+		case xhtml.TextToken:
+			b.WriteString(collapseSpace(string(z.Text())))
+		case xhtml.StartTagToken, xhtml.EndTagToken, xhtml.SelfClosingTagToken:
+			name, _ := z.TagName()
+			switch string(name) {
+			case "br":
+				b.WriteString("\n")
+			case "p", "div", "li", "tr", "h1", "h2", "h3", "h4", "h5", "h6", "blockquote", "pre", "ul", "ol", "table":
+				b.WriteString("\n")
+			}
+		}
+	}
+}
+
+// collapseSpace folds whitespace runs into single spaces, keeping a space at
+// either end so words in adjacent inline elements stay apart.
+func collapseSpace(s string) string {
+	var b strings.Builder
+
+	space := false
+
+	for _, r := range s {
+		if unicode.IsSpace(r) {
+			space = true
+
+			continue
+		}
+
+		if space {
+			b.WriteByte(' ')
+		}
+
+		space = false
+
+		b.WriteRune(r)
+	}
+
+	if space {
+		b.WriteByte(' ')
+	}
+
+	return b.String()
+}
+
+func collapseBlankLines(s string) string {
+	lines := strings.Split(s, "\n")
+	out := lines[:0]
+	blank := false
+
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			if !blank {
+				out = append(out, "")
+			}
+
+			blank = true
+
+			continue
+		}
+
+		out = append(out, line)
+		blank = false
+	}
+
+	return strings.Join(out, "\n")
+}
+
+// Render converts an outgoing body written in format to the HTML Front
+// expects. HTML bodies are sent unchanged.
+func Render(body, format string) (string, error) {
+	switch format {
+	case FormatMarkdown:
+		return ToHTML(body)
+	case FormatText:
+		return TextToHTML(body), nil
+	case FormatHTML, "":
+		return body, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+}
+
+// Convert turns an HTML body from Front into format for display.
+func Convert(body, format string) (string, error) {
+	switch format {
+	case FormatMarkdown:
+		return ToMarkdown(body)
+	case FormatText:
+		return ToText(body)
+	case FormatHTML, "":
+		return body, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+}
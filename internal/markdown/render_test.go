@@ -0,0 +1,51 @@
+package markdown
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestToHTML(t *testing.T) {
+	got, err := ToHTML("Hi **there**,\nsee [docs](https://example.com)\n\n<script>alert(1)</script>")
+	if err != nil {
+		t.Fatalf("ToHTML: %v", err)
+	}
+
+	for _, want := range []string{"<strong>there</strong>", "<br>", `<a href="https://example.com"`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in %q", want, got)
+		}
+	}
+
+	if strings.Contains(got, "<script>") {
+		t.Errorf("script not sanitized: %q", got)
+	}
+}
+
+func TestRender(t *testing.T) {
+	got, err := Render("a < b\nnext\n\nnew para", FormatText)
+	if err != nil || got != "<p>a &lt; b<br>next</p>\n<p>new para</p>" {
+		t.Fatalf("text: got %q, %v", got, err)
+	}
+
+	got, err = Render("<b>raw</b>", FormatHTML)
+	if err != nil || got != "<b>raw</b>" {
+		t.Fatalf("html: got %q, %v", got, err)
+	}
+
+	if _, err := Render("x", "rtf"); !errors.Is(err, ErrUnknownFormat) {
+		t.Fatalf("err = %v, want ErrUnknownFormat", err)
+	}
+}
+
+func TestToText(t *testing.T) {
+	got, err := ToText("<p>Hello  <b>world</b></p><p>Line one<br>Line two</p>")
+	if err != nil {
+		t.Fatalf("ToText: %v", err)
+	}
+
+	if got != "Hello world\n\nLine one\nLine two" {
+		t.Fatalf("got %q", got)
+	}
+}
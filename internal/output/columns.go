@@ -0,0 +1,460 @@
+package output
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+)
+
+var errUnknownField = errors.New("unknown field")
+
+// Column describes one selectable field of T in table output.
+type Column[T any] struct {
+	Name   string // selector used with --fields
+	Header string
+	Value  func(T) string
+	Width  int // truncate to this many cells in table output; 0 means never
+}
+
+// cell renders item, truncated to Width when truncate is set.
+func (col Column[T]) cell(item T, truncate bool) string {
+	if truncate && col.Width > 0 {
+		return Truncate(col.Value(item), col.Width)
+	}
+
+	return col.Value(item)
+}
+
+// Columns is the ordered set of named columns available for T.
+type Columns[T any] []Column[T]
+
+// Names returns the selector names of all registered columns.
+func (cs Columns[T]) Names() []string {
+	names := make([]string, len(cs))
+	for i, col := range cs {
+		names[i] = col.Name
+	}
+
+	return names
+}
+
+// Select returns the columns matching names, in order. Names without a
+// registered column fall back to the JSON field of T with that name.
+func (cs Columns[T]) Select(names []string) (Columns[T], error) {
+	out := make(Columns[T], 0, len(names))
+
+	for _, raw := range names {
+		name := strings.ToLower(strings.TrimSpace(raw))
+		if name == "" {
+			continue
+		}
+
+		col, ok := cs.lookup(name)
+		if !ok {
+			col, ok = jsonColumn[T](name)
+		}
+
+		if !ok {
+			return nil, fmt.Errorf("%w %q (available: %s)", errUnknownField, raw, strings.Join(cs.Names(), ", "))
+		}
+
+		out = append(out, col)
+	}
+
+	return out, nil
+}
+
+// Row renders item for a table using the named columns, skipping unknown
+// names.
+func (cs Columns[T]) Row(item T, names ...string) []string {
+	row := make([]string, 0, len(names))
+
+	for _, name := range names {
+		if col, ok := cs.lookup(name); ok {
+			row = append(row, col.cell(item, true))
+		}
+	}
+
+	return row
+}
+
+func (cs Columns[T]) lookup(name string) (Column[T], bool) {
+	for _, col := range cs {
+		if col.Name == name {
+			return col, true
+		}
+	}
+
+	return Column[T]{}, false
+}
+
+// jsonColumn builds a column for the struct field of T whose JSON name is name.
+func jsonColumn[T any](name string) (Column[T], bool) {
+	typ := reflect.TypeFor[T]()
+	if typ.Kind() != reflect.Struct {
+		return Column[T]{}, false
+	}
+
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+
+		tag, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if tag != name {
+			continue
+		}
+
+		return Column[T]{
+			Name:   name,
+			Header: strings.ToUpper(strings.ReplaceAll(name, "_", " ")),
+			Value: func(item T) string {
+				return formatField(name, reflect.ValueOf(item).Field(i))
+			},
+		}, true
+	}
+
+	return Column[T]{}, false
+}
+
+func formatField(name string, v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Float64:
+		if strings.HasSuffix(name, "_at") || strings.HasSuffix(name, "_since") {
+			return FormatTimestamp(v.Float())
+		}
+
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64)
+	case reflect.Pointer, reflect.Slice, reflect.Map:
+		if v.IsNil() {
+			return ""
+		}
+	default:
+	}
+
+	b, err := json.Marshal(v.Interface())
+	if err != nil {
+		return ""
+	}
+
+	return string(b)
+}
+
+// WriteRows renders items as a table. mode.Fields overrides the default
+// column names, and mode.Template replaces the table with one template
+// execution per item. Long values are only truncated in tables; CSV and
+// templates get them in full.
+func WriteRows[T any](w io.Writer, mode Mode, cols Columns[T], defaults []string, items []T) error {
+	if mode.Template != "" {
+		return WriteTemplate(w, mode.Template, items)
+	}
+
+	names := defaults
+	if len(mode.Fields) > 0 {
+		names = mode.Fields
+	}
+
+	selected, err := cols.Select(names)
+	if err != nil {
+		return err
+	}
+
+	tbl := NewModeTableWriter(w, mode)
+
+	headers := make([]string, len(selected))
+	for i, col := range selected {
+		headers[i] = col.Header
+	}
+
+	tbl.AddRow(headers...)
+
+	for _, item := range items {
+		row := make([]string, len(selected))
+		for i, col := range selected {
+			row[i] = col.cell(item, !mode.CSV)
+		}
+
+		tbl.AddRow(row...)
+	}
+
+	return tbl.Flush()
+}
+
+// WriteItem renders a single item as a one-row table, CSV record or template.
+// Callers only use it when mode.Columnar() is true; otherwise they print their
+// own detail view.
+func WriteItem[T any](w io.Writer, mode Mode, cols Columns[T], item T) error {
+	return WriteRows(w, mode, cols, cols.Names(), []T{item})
+}
+
+var templateFuncs = template.FuncMap{
+	"time":     FormatTimestamp,
+	"join":     strings.Join,
+	"truncate": func(width int, s string) string { return Truncate(s, width) },
+	"upper":    strings.ToUpper,
+	"lower":    strings.ToLower,
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+
+		return string(b), err
+	},
+}
+
+// WriteTemplate executes a Go template once per item. A newline is added
+// after each item unless the template already ends with one.
+func WriteTemplate[T any](w io.Writer, text string, items []T) error {
+	tmpl, err := template.New("output").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return fmt.Errorf("parse template: %w", err)
+	}
+
+	for _, item := range items {
+		if err := tmpl.Execute(w, item); err != nil {
+			return fmt.Errorf("execute template: %w", err)
+		}
+
+		if !strings.HasSuffix(text, "\n") {
+			fmt.Fprintln(w)
+		}
+	}
+
+	return nil
+}
+
+func authorName(a *api.Author) string {
+	if a == nil {
+		return "-"
+	}
+
+	if a.Email != "" {
+		return a.Email
+	}
+
+	return a.Username
+}
+
+func teammateName(tm api.Teammate) string {
+	name := strings.TrimSpace(tm.FirstName + " " + tm.LastName)
+	if name == "" {
+		name = tm.Username
+	}
+
+	return name
+}
+
+// ConversationColumns are the selectable columns for conversations.
+var ConversationColumns = Columns[api.Conversation]{
+	{Name: "id", Header: "ID", Value: func(c api.Conversation) string { return c.ID }},
+	{Name: "status", Header: "STATUS", Value: func(c api.Conversation) string { return c.Status }},
+	{Name: "assignee", Header: "ASSIGNEE", Value: func(c api.Conversation) string {
+		if c.Assignee == nil {
+			return "-"
+		}
+
+		if c.Assignee.Email != "" {
+			return c.Assignee.Email
+		}
+
+		return c.Assignee.Username
+	}},
+	{Name: "subject", Header: "SUBJECT", Value: func(c api.Conversation) string { return c.Subject }, Width: 50},
+	{Name: "tags", Header: "TAGS", Value: func(c api.Conversation) string {
+		names := make([]string, len(c.Tags))
+		for i, t := range c.Tags {
+			names[i] = t.Name
+		}
+
+		return strings.Join(names, ",")
+	}},
+	{Name: "inbox", Header: "INBOX", Value: func(c api.Conversation) string {
+		names := make([]string, len(c.Inboxes))
+		for i, inbox := range c.Inboxes {
+			names[i] = inbox.Name
+		}
+
+		return strings.Join(names, ",")
+	}},
+	{Name: "recipient", Header: "RECIPIENT", Value: func(c api.Conversation) string {
+		if c.Recipient == nil {
+			return ""
+		}
+
+		return c.Recipient.Handle
+	}},
+	{Name: "created", Header: "CREATED", Value: func(c api.Conversation) string { return FormatTimestamp(c.CreatedAt) }},
+	{Name: "updated", Header: "UPDATED", Value: func(c api.Conversation) string {
+		// Use waiting_since if available (snooze/activity time), else created_at
+		updated := c.WaitingSince
+		if updated == 0 {
+			updated = c.CreatedAt
+		}
+
+		return FormatTimestamp(updated)
+	}},
+	{Name: "waiting_since", Header: "WAITING SINCE", Value: func(c api.Conversation) string { return FormatTimestamp(c.WaitingSince) }},
+}
+
+var (
+	ConversationFields            = []string{"id", "status", "assignee", "subject", "created"}
+	ConversationFieldsWithUpdated = []string{"id", "status", "assignee", "subject", "created", "updated"}
+)
+
+// MessageColumns are the selectable columns for messages.
+var MessageColumns = Columns[api.Message]{
+	{Name: "id", Header: "ID", Value: func(m api.Message) string { return m.ID }},
+	{Name: "dir", Header: "DIR", Value: func(m api.Message) string {
+		if m.IsInbound {
+			return "IN"
+		}
+
+		return "OUT"
+	}},
+	{Name: "from", Header: "FROM", Value: func(m api.Message) string { return authorName(m.Author) }},
+	{Name: "preview", Header: "PREVIEW", Value: func(m api.Message) string { return m.Blurb }, Width: 60},
+	{Name: "date", Header: "DATE", Value: func(m api.Message) string { return FormatTimestamp(m.CreatedAt) }},
+	{Name: "subject", Header: "SUBJECT", Value: func(m api.Message) string { return m.Subject }, Width: 50},
+	{Name: "type", Header: "TYPE", Value: func(m api.Message) string { return m.Type }},
+	{Name: "attachments", Header: "ATTACHMENTS", Value: func(m api.Message) string { return strconv.Itoa(len(m.Attachments)) }},
+}
+
+var MessageFields = []string{"id", "dir", "from", "preview", "date"}
+
+// CommentColumns are the selectable columns for comments.
+var CommentColumns = Columns[api.Comment]{
+	{Name: "id", Header: "ID", Value: func(c api.Comment) string { return c.ID }},
+	{Name: "author", Header: "AUTHOR", Value: func(c api.Comment) string { return authorName(c.Author) }},
+	{Name: "body", Header: "BODY", Value: func(c api.Comment) string { return c.Body }, Width: 50},
+	{Name: "date", Header: "DATE", Value: func(c api.Comment) string { return FormatTimestamp(c.PostedAt) }},
+}
+
+var CommentFields = []string{"id", "author", "body", "date"}
+
+// ContactNoteColumns are the selectable columns for contact notes.
+var ContactNoteColumns = Columns[api.ContactNote]{
+	{Name: "id", Header: "ID", Value: func(n api.ContactNote) string { return n.ID }},
+	{Name: "author", Header: "AUTHOR", Value: func(n api.ContactNote) string { return authorName(n.Author) }},
+	{Name: "note", Header: "NOTE", Value: func(n api.ContactNote) string { return n.Body }, Width: 50},
+	{Name: "date", Header: "DATE", Value: func(n api.ContactNote) string { return FormatTimestamp(n.CreatedAt) }},
+}
+
+var ContactNoteFields = []string{"id", "author", "note", "date"}
+
+// TagColumns are the selectable columns for tags.
+var TagColumns = Columns[api.Tag]{
+	{Name: "id", Header: "ID", Value: func(t api.Tag) string { return t.ID }},
+	{Name: "name", Header: "NAME", Value: func(t api.Tag) string { return t.Name }},
+	{Name: "color", Header: "COLOR", Value: func(t api.Tag) string { return t.Highlight }},
+	{Name: "description", Header: "DESCRIPTION", Value: func(t api.Tag) string { return t.Description }},
+	{Name: "parent", Header: "PARENT", Value: func(t api.Tag) string { return t.ParentTagID }},
+	{Name: "private", Header: "PRIVATE", Value: func(t api.Tag) string { return strconv.FormatBool(t.IsPrivate) }},
+}
+
+var TagFields = []string{"id", "name", "color"}
+
+// InboxColumns are the selectable columns for inboxes.
+var InboxColumns = Columns[api.Inbox]{
+	{Name: "id", Header: "ID", Value: func(i api.Inbox) string { return i.ID }},
+	{Name: "name", Header: "NAME", Value: func(i api.Inbox) string { return i.Name }},
+	{Name: "private", Header: "PRIVATE", Value: func(i api.Inbox) string { return strconv.FormatBool(i.IsPrivate) }},
+}
+
+var InboxFields = []string{"id", "name"}
+
+// TeammateColumns are the selectable columns for teammates.
+var TeammateColumns = Columns[api.Teammate]{
+	{Name: "id", Header: "ID", Value: func(tm api.Teammate) string { return tm.ID }},
+	{Name: "email", Header: "EMAIL", Value: func(tm api.Teammate) string { return tm.Email }},
+	{Name: "name", Header: "NAME", Value: teammateName},
+	{Name: "username", Header: "USERNAME", Value: func(tm api.Teammate) string { return tm.Username }},
+	{Name: "admin", Header: "ADMIN", Value: func(tm api.Teammate) string { return strconv.FormatBool(tm.IsAdmin) }},
+	{Name: "available", Header: "AVAILABLE", Value: func(tm api.Teammate) string { return strconv.FormatBool(tm.IsAvailable) }},
+}
+
+var TeammateFields = []string{"id", "email", "name"}
+
+// ContactColumns are the selectable columns for contacts.
+var ContactColumns = Columns[api.Contact]{
+	{Name: "id", Header: "ID", Value: func(c api.Contact) string { return c.ID }},
+	{Name: "name", Header: "NAME", Value: func(c api.Contact) string { return c.Name }},
+	{Name: "handle", Header: "HANDLE", Value: func(c api.Contact) string {
+		if len(c.Handles) == 0 {
+			return "-"
+		}
+
+		return c.Handles[0].Handle
+	}},
+	{Name: "handles", Header: "HANDLES", Value: func(c api.Contact) string {
+		handles := make([]string, len(c.Handles))
+		for i, h := range c.Handles {
+			handles[i] = h.Handle
+		}
+
+		return strings.Join(handles, ",")
+	}},
+	{Name: "description", Header: "DESCRIPTION", Value: func(c api.Contact) string { return c.Description }, Width: 50},
+}
+
+var ContactFields = []string{"id", "name", "handle"}
+
+// HandleColumns are the selectable columns for contact handles.
+var HandleColumns = Columns[api.Handle]{
+	{Name: "handle", Header: "HANDLE", Value: func(h api.Handle) string { return h.Handle }},
+	{Name: "source", Header: "SOURCE", Value: func(h api.Handle) string { return h.Source }},
+}
+
+var HandleFields = []string{"handle", "source"}
+
+// ChannelColumns are the selectable columns for channels.
+var ChannelColumns = Columns[api.Channel]{
+	{Name: "id", Header: "ID", Value: func(ch api.Channel) string { return ch.ID }},
+	{Name: "type", Header: "TYPE", Value: func(ch api.Channel) string { return ch.Type }},
+	{Name: "name", Header: "NAME", Value: func(ch api.Channel) string { return ch.Name }},
+	{Name: "address", Header: "ADDRESS", Value: func(ch api.Channel) string { return ch.Address }},
+	{Name: "send_as", Header: "SEND AS", Value: func(ch api.Channel) string { return ch.SendAs }},
+	{Name: "private", Header: "PRIVATE", Value: func(ch api.Channel) string { return strconv.FormatBool(ch.IsPrivate) }},
+}
+
+var ChannelFields = []string{"id", "type", "name", "address"}
+
+// DraftColumns are the selectable columns for drafts.
+var DraftColumns = Columns[api.Draft]{
+	{Name: "id", Header: "ID", Value: func(d api.Draft) string { return d.ID }},
+	{Name: "version", Header: "VERSION", Value: func(d api.Draft) string { return strconv.Itoa(d.Version) }},
+	{Name: "subject", Header: "SUBJECT", Value: func(d api.Draft) string { return d.Subject }},
+	{Name: "created", Header: "CREATED", Value: func(d api.Draft) string { return FormatTimestamp(d.CreatedAt) }},
+	{Name: "author", Header: "AUTHOR", Value: func(d api.Draft) string { return authorName(d.Author) }},
+	{Name: "to", Header: "TO", Value: func(d api.Draft) string { return strings.Join(d.To, ",") }},
+}
+
+var DraftFields = []string{"id", "version", "subject", "created"}
+
+// TemplateColumns are the selectable columns for message templates.
+var TemplateColumns = Columns[api.Template]{
+	{Name: "id", Header: "ID", Value: func(t api.Template) string { return t.ID }},
+	{Name: "name", Header: "NAME", Value: func(t api.Template) string { return t.Name }},
+	{Name: "subject", Header: "SUBJECT", Value: func(t api.Template) string { return t.Subject }},
+}
+
+var TemplateFields = []string{"id", "name", "subject"}
+
+// AttachmentColumns are the selectable columns for attachments.
+var AttachmentColumns = Columns[api.Attachment]{
+	{Name: "id", Header: "ID", Value: func(a api.Attachment) string { return a.ID }},
+	{Name: "filename", Header: "FILENAME", Value: func(a api.Attachment) string { return a.Filename }},
+	{Name: "type", Header: "TYPE", Value: func(a api.Attachment) string { return a.ContentType }},
+	{Name: "size", Header: "SIZE", Value: func(a api.Attachment) string { return strconv.FormatInt(a.Size, 10) }},
+	{Name: "url", Header: "URL", Value: func(a api.Attachment) string { return a.URL }},
+}
+
+var AttachmentFields = []string{"id", "filename", "type", "size"}
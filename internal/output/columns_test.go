@@ -0,0 +1,92 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+)
+
+func TestWriteRowsSelectsFields(t *testing.T) {
+	var buf bytes.Buffer
+
+	convs := []api.Conversation{{
+		ID:           "cnv_1",
+		Subject:      "Hello",
+		Tags:         []api.Tag{{Name: "vip"}, {Name: "billing"}},
+		Inboxes:      []api.Inbox{{Name: "Support"}},
+		WaitingSince: 1700000000,
+	}}
+
+	mode := Mode{Plain: true, Fields: []string{"id", "TAGS", "inbox", "waiting_since"}}
+	if err := WriteRows(&buf, mode, ConversationColumns, ConversationFields, convs); err != nil {
+		t.Fatalf("WriteRows: %v", err)
+	}
+
+	want := "ID\tTAGS\tINBOX\tWAITING SINCE\ncnv_1\tvip,billing\tSupport\t" + FormatTimestamp(1700000000) + "\n"
+	if got := buf.String(); got != want {
+		t.Fatalf("unexpected output:\n%q\nwant:\n%q", got, want)
+	}
+}
+
+func TestSelectFallsBackToJSONField(t *testing.T) {
+	cols, err := TagColumns.Select([]string{"parent_tag_id", "created_at"})
+	if err != nil {
+		t.Fatalf("Select: %v", err)
+	}
+
+	tag := api.Tag{ParentTagID: "tag_p", CreatedAt: 1700000000}
+	if got := cols[0].Value(tag); got != "tag_p" {
+		t.Fatalf("unexpected parent: %q", got)
+	}
+
+	if got := cols[1].Value(tag); got != FormatTimestamp(1700000000) {
+		t.Fatalf("unexpected created_at: %q", got)
+	}
+}
+
+func TestSelectUnknownField(t *testing.T) {
+	_, err := TagColumns.Select([]string{"nope"})
+	if err == nil || !strings.Contains(err.Error(), "available: id, name") {
+		t.Fatalf("expected unknown field error, got %v", err)
+	}
+}
+
+func TestWriteRowsTemplate(t *testing.T) {
+	var buf bytes.Buffer
+
+	convs := []api.Conversation{{ID: "cnv_1", Subject: "Hi"}, {ID: "cnv_2", Subject: "Yo"}}
+
+	mode := Mode{Template: `{{.ID}} {{.Subject | upper}}`}
+	if err := WriteRows(&buf, mode, ConversationColumns, ConversationFields, convs); err != nil {
+		t.Fatalf("WriteRows: %v", err)
+	}
+
+	if got := buf.String(); got != "cnv_1 HI\ncnv_2 YO\n" {
+		t.Fatalf("unexpected output: %q", got)
+	}
+}
+
+func TestWriteRowsTruncatesOnlyTables(t *testing.T) {
+	subject := strings.Repeat("long subject ", 10)
+	convs := []api.Conversation{{ID: "cnv_1", Subject: subject}}
+
+	var csv bytes.Buffer
+	if err := WriteRows(&csv, Mode{CSV: true}, ConversationColumns, []string{"id", "subject"}, convs); err != nil {
+		t.Fatalf("WriteRows csv: %v", err)
+	}
+
+	if got := csv.String(); got != "ID,SUBJECT\ncnv_1,"+subject+"\n" {
+		t.Fatalf("expected the full subject in CSV, got %q", got)
+	}
+
+	var table bytes.Buffer
+	if err := WriteRows(&table, Mode{}, ConversationColumns, []string{"id", "subject"}, convs); err != nil {
+		t.Fatalf("WriteRows table: %v", err)
+	}
+
+	if strings.Contains(table.String(), subject) || !strings.Contains(table.String(), "...") {
+		t.Fatalf("expected a truncated subject in the table, got %q", table.String())
+	}
+}
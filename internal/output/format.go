@@ -6,12 +6,34 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"reflect"
 	"strings"
 )
 
+// Mode selects the output format. NDJSON and YAML imply JSON so commands
+// that branch on JSON pick the structured path for them. CSV is a table
+// format, like Plain.
 type Mode struct {
-	JSON  bool
-	Plain bool
+	JSON   bool
+	NDJSON bool
+	YAML   bool
+	Plain  bool
+	CSV    bool
+
+	// Fields selects table columns by name; Template replaces the table
+	// with a Go template executed per item.
+	Fields   []string
+	Template string
+
+	// JQ filters structured output before it is encoded.
+	JQ *Filter
+}
+
+// Columnar reports whether single items should be rendered through their
+// columns rather than a command's detail view: the user picked columns or a
+// template, or asked for CSV.
+func (m Mode) Columnar() bool {
+	return len(m.Fields) > 0 || m.Template != "" || m.CSV
 }
 
 type ctxKey struct{}
@@ -36,12 +58,41 @@ func IsJSON(ctx context.Context) bool {
 
 func FromEnv() Mode {
 	return Mode{
-		JSON:  envBool("FRONT_JSON"),
-		Plain: envBool("FRONT_PLAIN"),
+		JSON:   envBool("FRONT_JSON"),
+		NDJSON: envBool("FRONT_NDJSON"),
+		YAML:   envBool("FRONT_YAML"),
+		Plain:  envBool("FRONT_PLAIN"),
+		CSV:    envBool("FRONT_CSV"),
 	}
 }
 
-func WriteJSON(w io.Writer, v any) error {
+// Write writes v in the structured format selected by mode.
+func Write(w io.Writer, mode Mode, v any) error {
+	if mode.JQ != nil {
+		return writeFiltered(w, mode, v)
+	}
+
+	switch {
+	case mode.NDJSON:
+		return WriteNDJSON(w, v)
+	case mode.YAML:
+		return WriteYAML(w, v)
+	default:
+		return encodeJSON(w, v)
+	}
+}
+
+// WriteJSON writes v as indented JSON, through mode.JQ when one is set, so
+// callers writing JSON directly still honour --jq.
+func WriteJSON(w io.Writer, mode Mode, v any) error {
+	if mode.JQ != nil {
+		return writeFiltered(w, Mode{JSON: true, JQ: mode.JQ}, v)
+	}
+
+	return encodeJSON(w, v)
+}
+
+func encodeJSON(w io.Writer, v any) error {
 	enc := json.NewEncoder(w)
 	enc.SetEscapeHTML(false)
 	enc.SetIndent("", "  ")
@@ -53,6 +104,44 @@ func WriteJSON(w io.Writer, v any) error {
 	return nil
 }
 
+// itemLister is implemented by list responses so NDJSON can emit one line per result.
+type itemLister interface {
+	ResultItems() []any
+}
+
+// WriteNDJSON writes v as newline-delimited JSON. List responses and slices
+// produce one compact object per element; anything else produces one line.
+func WriteNDJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+
+	for _, item := range ndjsonItems(v) {
+		if err := enc.Encode(item); err != nil {
+			return fmt.Errorf("encode json: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func ndjsonItems(v any) []any {
+	if lister, ok := v.(itemLister); ok {
+		return lister.ResultItems()
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return []any{v}
+	}
+
+	items := make([]any, rv.Len())
+	for i := range items {
+		items[i] = rv.Index(i).Interface()
+	}
+
+	return items
+}
+
 func envBool(key string) bool {
 	v := strings.TrimSpace(strings.ToLower(os.Getenv(key)))
 	switch v {
@@ -0,0 +1,119 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+)
+
+func TestWriteNDJSONEmitsOneLinePerResult(t *testing.T) {
+	var buf bytes.Buffer
+
+	resp := api.ListResponse[api.Tag]{Results: []api.Tag{{ID: "tag_1", Name: "a"}, {ID: "tag_2", Name: "b"}}}
+	if err := WriteNDJSON(&buf, resp); err != nil {
+		t.Fatalf("WriteNDJSON: %v", err)
+	}
+
+	want := "{\"id\":\"tag_1\",\"name\":\"a\",\"_links\":{}}\n{\"id\":\"tag_2\",\"name\":\"b\",\"_links\":{}}\n"
+	if got := buf.String(); got != want {
+		t.Fatalf("unexpected output:\n%s", got)
+	}
+}
+
+func TestWriteNDJSONSingleValue(t *testing.T) {
+	var buf bytes.Buffer
+
+	if err := Write(&buf, Mode{JSON: true, NDJSON: true}, map[string]string{"id": "cnv_1"}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	if got := buf.String(); got != "{\"id\":\"cnv_1\"}\n" {
+		t.Fatalf("unexpected output: %q", got)
+	}
+}
+
+func TestWriteYAMLUsesJSONKeys(t *testing.T) {
+	var buf bytes.Buffer
+
+	tag := api.Tag{ID: "tag_1", Name: "123", ParentTagID: "tag_p"}
+	if err := Write(&buf, Mode{JSON: true, YAML: true}, tag); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	want := "id: tag_1\nname: \"123\"\nparent_tag_id: tag_p\n_links: {}\n"
+	if got := buf.String(); got != want {
+		t.Fatalf("unexpected output:\n%s", got)
+	}
+}
+
+func TestWriteJQFilter(t *testing.T) {
+	filter, err := CompileFilter(`._results | map(select(.name != "b")) | .[] | {id, upper: (.name | ascii_upcase)}`)
+	if err != nil {
+		t.Fatalf("CompileFilter: %v", err)
+	}
+
+	var buf bytes.Buffer
+
+	resp := api.ListResponse[api.Tag]{Results: []api.Tag{{ID: "tag_1", Name: "a"}, {ID: "tag_2", Name: "b"}}}
+	if err := Write(&buf, Mode{JSON: true, NDJSON: true, JQ: filter}, map[string]any{"_results": resp.Results}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	if got := buf.String(); got != "{\"id\":\"tag_1\",\"upper\":\"A\"}\n" {
+		t.Fatalf("unexpected output: %q", got)
+	}
+}
+
+func TestWriteJQRawStrings(t *testing.T) {
+	filter, err := CompileFilter(`.id`)
+	if err != nil {
+		t.Fatalf("CompileFilter: %v", err)
+	}
+
+	var buf bytes.Buffer
+
+	resp := api.ListResponse[api.Tag]{Results: []api.Tag{{ID: "tag_1"}, {ID: "tag_2"}}}
+	if err := Write(&buf, Mode{JSON: true, NDJSON: true, JQ: filter}, resp); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	if got := buf.String(); got != "tag_1\ntag_2\n" {
+		t.Fatalf("unexpected output: %q", got)
+	}
+}
+
+func TestWriteJQYAMLSeparatesDocumentsOnly(t *testing.T) {
+	filter, err := CompileFilter(`.[] | (.n, {id})`)
+	if err != nil {
+		t.Fatalf("CompileFilter: %v", err)
+	}
+
+	var buf bytes.Buffer
+
+	input := []map[string]any{{"id": "a", "n": 1}, {"id": "b", "n": 2}}
+	if err := Write(&buf, Mode{JSON: true, YAML: true, JQ: filter}, input); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	if got := buf.String(); got != "1\nid: a\n2\n---\nid: b\n" {
+		t.Fatalf("unexpected output: %q", got)
+	}
+}
+
+func TestWriteJSONAppliesJQ(t *testing.T) {
+	filter, err := CompileFilter(`{id}`)
+	if err != nil {
+		t.Fatalf("CompileFilter: %v", err)
+	}
+
+	var buf bytes.Buffer
+
+	if err := WriteJSON(&buf, Mode{JQ: filter}, api.Tag{ID: "tag_1", Name: "a"}); err != nil {
+		t.Fatalf("WriteJSON: %v", err)
+	}
+
+	if got := buf.String(); got != "{\n  \"id\": \"tag_1\"\n}\n" {
+		t.Fatalf("unexpected output: %q", got)
+	}
+}
@@ -0,0 +1,128 @@
+package output
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/itchyny/gojq"
+)
+
+// Filter is a compiled --jq expression.
+type Filter struct {
+	code *gojq.Code
+}
+
+// CompileFilter parses and compiles a jq expression.
+func CompileFilter(expr string) (*Filter, error) {
+	query, err := gojq.Parse(expr)
+	if err != nil {
+		return nil, fmt.Errorf("parse jq expression: %w", err)
+	}
+
+	code, err := gojq.Compile(query)
+	if err != nil {
+		return nil, fmt.Errorf("compile jq expression: %w", err)
+	}
+
+	return &Filter{code: code}, nil
+}
+
+// Apply runs the filter against the JSON representation of v and returns
+// every value it emits.
+func (f *Filter) Apply(v any) ([]any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("encode json: %w", err)
+	}
+
+	var input any
+	if err := json.Unmarshal(data, &input); err != nil {
+		return nil, fmt.Errorf("decode json: %w", err)
+	}
+
+	var results []any
+
+	iter := f.code.Run(input)
+
+	for {
+		out, ok := iter.Next()
+		if !ok {
+			break
+		}
+
+		if err, isErr := out.(error); isErr {
+			var halt *gojq.HaltError
+			if errors.As(err, &halt) && halt.Value() == nil {
+				break
+			}
+
+			return nil, fmt.Errorf("jq: %w", err)
+		}
+
+		results = append(results, out)
+	}
+
+	return results, nil
+}
+
+// writeFiltered applies mode.JQ and writes each result. Like `jq -r`, string
+// results are written as raw lines. In NDJSON mode the filter runs once per
+// item, the way jq processes a stream of lines.
+func writeFiltered(w io.Writer, mode Mode, v any) error {
+	inputs := []any{v}
+	if mode.NDJSON {
+		inputs = ndjsonItems(v)
+	}
+
+	documents := 0
+
+	for _, input := range inputs {
+		results, err := mode.JQ.Apply(input)
+		if err != nil {
+			return err
+		}
+
+		for _, result := range results {
+			if err := writeFilterResult(w, mode, result, &documents); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// writeFilterResult writes one filter result. documents counts the YAML
+// documents written so far: objects and arrays after the first are
+// separated by "---", while scalars are written as plain lines.
+func writeFilterResult(w io.Writer, mode Mode, result any, documents *int) error {
+	if s, ok := result.(string); ok {
+		if _, err := fmt.Fprintln(w, s); err != nil {
+			return fmt.Errorf("write output: %w", err)
+		}
+
+		return nil
+	}
+
+	switch {
+	case mode.NDJSON:
+		return WriteNDJSON(w, []any{result})
+	case mode.YAML:
+		switch result.(type) {
+		case map[string]any, []any:
+			if *documents > 0 {
+				if _, err := fmt.Fprintln(w, "---"); err != nil {
+					return fmt.Errorf("write output: %w", err)
+				}
+			}
+
+			*documents++
+		}
+
+		return WriteYAML(w, result)
+	default:
+		return encodeJSON(w, result)
+	}
+}
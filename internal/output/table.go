@@ -1,17 +1,23 @@
 package output
 
 import (
+	"encoding/csv"
 	"fmt"
 	"io"
 	"strings"
-	"text/tabwriter"
+
+	"github.com/rivo/uniseg"
 
 	"github.com/dedene/frontapp-cli/internal/api"
 )
 
-// Table provides simple table output using tabwriter.
+const columnPadding = 2
+
+// Table buffers rows and aligns columns by terminal display width, so
+// wide (CJK) and combined characters line up correctly.
 type Table struct {
-	w *tabwriter.Writer
+	out  io.Writer
+	rows [][]string
 }
 
 type TableWriter interface {
@@ -32,9 +38,7 @@ func (t *PlainTable) Flush() error {
 }
 
 func NewTable(out io.Writer) *Table {
-	return &Table{
-		w: tabwriter.NewWriter(out, 0, 0, 2, ' ', 0),
-	}
+	return &Table{out: out}
 }
 
 func NewPlainTable(out io.Writer) *PlainTable {
@@ -49,154 +53,154 @@ func NewTableWriter(out io.Writer, plain bool) TableWriter {
 	return NewTable(out)
 }
 
-func (t *Table) AddRow(cols ...string) {
-	fmt.Fprintln(t.w, strings.Join(cols, "\t"))
+// NewModeTableWriter returns the table writer for mode: CSV, TSV or an
+// aligned table.
+func NewModeTableWriter(out io.Writer, mode Mode) TableWriter {
+	if mode.CSV {
+		return NewCSVTable(out)
+	}
+
+	return NewTableWriter(out, mode.Plain)
 }
 
-func (t *Table) Flush() error {
-	if err := t.w.Flush(); err != nil {
-		return fmt.Errorf("flush table: %w", err)
+// CSVTable writes rows as RFC 4180 CSV, quoting fields as needed.
+type CSVTable struct {
+	w *csv.Writer
+}
+
+func NewCSVTable(out io.Writer) *CSVTable {
+	return &CSVTable{w: csv.NewWriter(out)}
+}
+
+func (t *CSVTable) AddRow(cols ...string) {
+	// Write errors are sticky and reported by Flush.
+	_ = t.w.Write(cols)
+}
+
+func (t *CSVTable) Flush() error {
+	t.w.Flush()
+
+	if err := t.w.Error(); err != nil {
+		return fmt.Errorf("flush csv: %w", err)
 	}
 
 	return nil
 }
 
-// FormatConversation formats a conversation for table output.
-func FormatConversation(conv api.Conversation) []string {
-	assignee := "-"
-	if conv.Assignee != nil {
-		assignee = conv.Assignee.Email
-		if assignee == "" {
-			assignee = conv.Assignee.Username
+func (t *Table) AddRow(cols ...string) {
+	t.rows = append(t.rows, cols)
+}
+
+func (t *Table) Flush() error {
+	var widths []int
+
+	for _, row := range t.rows {
+		for i, col := range row {
+			if i >= len(widths) {
+				widths = append(widths, 0)
+			}
+
+			widths[i] = max(widths[i], uniseg.StringWidth(col))
 		}
 	}
 
-	subject := conv.Subject
-	if len(subject) > 50 {
-		subject = subject[:47] + "..."
-	}
+	var b strings.Builder
 
-	return []string{
-		conv.ID,
-		conv.Status,
-		assignee,
-		subject,
-		FormatTimestamp(conv.CreatedAt),
-	}
-}
+	for _, row := range t.rows {
+		for i, col := range row {
+			b.WriteString(col)
 
-// FormatConversationWithUpdated formats a conversation with UPDATED column.
-// Shows waiting_since for snoozed, otherwise shows created_at.
-func FormatConversationWithUpdated(conv api.Conversation) []string {
-	assignee := "-"
-	if conv.Assignee != nil {
-		assignee = conv.Assignee.Email
-		if assignee == "" {
-			assignee = conv.Assignee.Username
+			if i < len(row)-1 {
+				b.WriteString(strings.Repeat(" ", widths[i]-uniseg.StringWidth(col)+columnPadding))
+			}
 		}
-	}
 
-	subject := conv.Subject
-	if len(subject) > 50 {
-		subject = subject[:47] + "..."
+		b.WriteByte('\n')
 	}
 
-	// Use waiting_since if available (snooze/activity time), else created_at
-	updated := conv.WaitingSince
-	if updated == 0 {
-		updated = conv.CreatedAt
-	}
+	t.rows = nil
 
-	return []string{
-		conv.ID,
-		conv.Status,
-		assignee,
-		subject,
-		FormatTimestamp(conv.CreatedAt),
-		FormatTimestamp(updated),
+	if _, err := io.WriteString(t.out, b.String()); err != nil {
+		return fmt.Errorf("flush table: %w", err)
 	}
+
+	return nil
 }
 
-// FormatMessage formats a message for table output.
-func FormatMessage(msg api.Message) []string {
-	direction := "OUT"
-	if msg.IsInbound {
-		direction = "IN"
+// Truncate shortens s to at most width display cells, ending in "..." when
+// cut. It never splits a multi-byte character or grapheme cluster.
+func Truncate(s string, width int) string {
+	if uniseg.StringWidth(s) <= width {
+		return s
 	}
 
-	author := "-"
-	if msg.Author != nil {
-		author = msg.Author.Email
-		if author == "" {
-			author = msg.Author.Username
-		}
-	}
+	const ellipsis = "..."
 
-	blurb := msg.Blurb
-	if len(blurb) > 60 {
-		blurb = blurb[:57] + "..."
+	limit := width - len(ellipsis)
+	if limit <= 0 {
+		return ellipsis[:max(width, 0)]
 	}
 
-	return []string{
-		msg.ID,
-		direction,
-		author,
-		blurb,
-		FormatTimestamp(msg.CreatedAt),
+	var (
+		b     strings.Builder
+		used  int
+		state = -1
+		rest  = s
+		g     string
+		w     int
+	)
+
+	for rest != "" {
+		g, rest, w, state = uniseg.FirstGraphemeClusterInString(rest, state)
+		if used+w > limit {
+			break
+		}
+
+		b.WriteString(g)
+
+		used += w
 	}
+
+	return b.String() + ellipsis
+}
+
+// FormatConversation formats a conversation for table output.
+func FormatConversation(conv api.Conversation) []string {
+	return ConversationColumns.Row(conv, ConversationFields...)
+}
+
+// FormatConversationWithUpdated formats a conversation with UPDATED column.
+// Shows waiting_since for snoozed, otherwise shows created_at.
+func FormatConversationWithUpdated(conv api.Conversation) []string {
+	return ConversationColumns.Row(conv, ConversationFieldsWithUpdated...)
+}
+
+// FormatMessage formats a message for table output.
+func FormatMessage(msg api.Message) []string {
+	return MessageColumns.Row(msg, MessageFields...)
 }
 
 // FormatTag formats a tag for table output.
 func FormatTag(tag api.Tag) []string {
-	return []string{
-		tag.ID,
-		tag.Name,
-		tag.Highlight,
-	}
+	return TagColumns.Row(tag, TagFields...)
 }
 
 // FormatInbox formats an inbox for table output.
 func FormatInbox(inbox api.Inbox) []string {
-	return []string{
-		inbox.ID,
-		inbox.Name,
-	}
+	return InboxColumns.Row(inbox, InboxFields...)
 }
 
 // FormatTeammate formats a teammate for table output.
 func FormatTeammate(tm api.Teammate) []string {
-	name := strings.TrimSpace(tm.FirstName + " " + tm.LastName)
-	if name == "" {
-		name = tm.Username
-	}
-
-	return []string{
-		tm.ID,
-		tm.Email,
-		name,
-	}
+	return TeammateColumns.Row(tm, TeammateFields...)
 }
 
 // FormatContact formats a contact for table output.
 func FormatContact(contact api.Contact) []string {
-	handle := "-"
-	if len(contact.Handles) > 0 {
-		handle = contact.Handles[0].Handle
-	}
-
-	return []string{
-		contact.ID,
-		contact.Name,
-		handle,
-	}
+	return ContactColumns.Row(contact, ContactFields...)
 }
 
 // FormatChannel formats a channel for table output.
 func FormatChannel(ch api.Channel) []string {
-	return []string{
-		ch.ID,
-		ch.Type,
-		ch.Name,
-		ch.Address,
-	}
+	return ChannelColumns.Row(ch, ChannelFields...)
 }
@@ -19,3 +19,55 @@ func TestPlainTableWriterUsesTabs(t *testing.T) {
 		t.Fatalf("unexpected output: %q", got)
 	}
 }
+
+func TestTableAlignsByDisplayWidth(t *testing.T) {
+	var buf bytes.Buffer
+
+	tbl := NewTableWriter(&buf, false)
+	tbl.AddRow("ID", "SUBJECT")
+	tbl.AddRow("日本", "x")
+
+	if err := tbl.Flush(); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+
+	if got := buf.String(); got != "ID    SUBJECT\n日本  x\n" {
+		t.Fatalf("unexpected output: %q", got)
+	}
+}
+
+func TestTruncateKeepsRunesIntact(t *testing.T) {
+	tests := []struct {
+		in    string
+		width int
+		want  string
+	}{
+		{"short", 10, "short"},
+		{"abcdefghij", 8, "abcde..."},
+		{"ééééééé", 6, "ééé..."},
+		{"日本語のテキスト", 9, "日本語..."},
+		{"日本語のテキスト", 8, "日本..."},
+	}
+
+	for _, tt := range tests {
+		if got := Truncate(tt.in, tt.width); got != tt.want {
+			t.Errorf("Truncate(%q, %d) = %q, want %q", tt.in, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestCSVTableQuotesFields(t *testing.T) {
+	var buf bytes.Buffer
+
+	tbl := NewModeTableWriter(&buf, Mode{CSV: true})
+	tbl.AddRow("ID", "SUBJECT")
+	tbl.AddRow("cnv_1", `Re: "refund", please`)
+
+	if err := tbl.Flush(); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+
+	if got := buf.String(); got != "ID,SUBJECT\ncnv_1,\"Re: \"\"refund\"\", please\"\n" {
+		t.Fatalf("unexpected output: %q", got)
+	}
+}
@@ -0,0 +1,63 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// WriteYAML writes v as YAML. Values are encoded through their JSON
+// representation first, so keys and omitempty behaviour match --json.
+func WriteYAML(w io.Writer, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("encode json: %w", err)
+	}
+
+	// JSON is valid YAML; decoding into a node keeps the key order.
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("decode json as yaml: %w", err)
+	}
+
+	blockStyle(&doc)
+
+	var buf bytes.Buffer
+
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+
+	if err := enc.Encode(&doc); err != nil {
+		return fmt.Errorf("encode yaml: %w", err)
+	}
+
+	if err := enc.Close(); err != nil {
+		return fmt.Errorf("encode yaml: %w", err)
+	}
+
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("write yaml: %w", err)
+	}
+
+	return nil
+}
+
+// blockStyle clears the flow and quoting styles inherited from JSON so the
+// output reads as ordinary block YAML.
+func blockStyle(n *yaml.Node) {
+	switch n.Kind {
+	case yaml.ScalarNode:
+		if n.Tag == "!!str" {
+			n.Style = 0
+		}
+	default:
+		n.Style = 0
+	}
+
+	for _, child := range n.Content {
+		blockStyle(child)
+	}
+}
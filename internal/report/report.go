@@ -0,0 +1,414 @@
+// Package report computes support metrics (response times, volume and
+// backlog) from conversation and message timestamps.
+package report
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+)
+
+var (
+	// ErrUnknownGroup is returned for a group-by value not in Groups.
+	ErrUnknownGroup = errors.New("unknown group-by")
+	// ErrPercentile is returned for a percentile outside 1-100.
+	ErrPercentile = errors.New("percentile out of range 1-100")
+)
+
+// Group-by dimensions.
+const (
+	GroupNone     = "none"
+	GroupInbox    = "inbox"
+	GroupTag      = "tag"
+	GroupAssignee = "assignee"
+	GroupDay      = "day"
+	GroupWeek     = "week"
+)
+
+// Groups lists the supported group-by values.
+var Groups = []string{GroupNone, GroupInbox, GroupTag, GroupAssignee, GroupDay, GroupWeek}
+
+// Labels for conversations without a value in the group-by dimension.
+const (
+	allLabel     = "all"
+	missingLabel = "(none)"
+)
+
+// Conversation is a conversation with its messages.
+type Conversation struct {
+	api.Conversation
+
+	Messages []api.Message
+}
+
+// Options controls how conversations are grouped and summarized.
+type Options struct {
+	GroupBy     string
+	Percentiles []int
+	// Location is used for day and week buckets; defaults to time.Local.
+	Location *time.Location
+}
+
+func (o Options) validate() error {
+	if o.GroupBy != "" && !slices.Contains(Groups, o.GroupBy) {
+		return fmt.Errorf("%w: %s", ErrUnknownGroup, o.GroupBy)
+	}
+
+	return nil
+}
+
+// groups returns the buckets c falls into. A conversation with several
+// tags or inboxes counts once in each.
+func (o Options) groups(c api.Conversation) []string {
+	loc := o.Location
+	if loc == nil {
+		loc = time.Local
+	}
+
+	var keys []string
+
+	switch o.GroupBy {
+	case GroupInbox:
+		for _, inbox := range c.Inboxes {
+			keys = append(keys, cmp.Or(inbox.Name, inbox.ID))
+		}
+	case GroupTag:
+		for _, tag := range c.Tags {
+			keys = append(keys, cmp.Or(tag.Name, tag.ID))
+		}
+	case GroupAssignee:
+		if c.Assignee != nil {
+			keys = append(keys, cmp.Or(c.Assignee.Email, c.Assignee.Username, c.Assignee.ID))
+		}
+	case GroupDay:
+		return []string{unixTime(c.CreatedAt).In(loc).Format(time.DateOnly)}
+	case GroupWeek:
+		year, week := unixTime(c.CreatedAt).In(loc).ISOWeek()
+
+		return []string{fmt.Sprintf("%d-W%02d", year, week)}
+	default:
+		return []string{allLabel}
+	}
+
+	if len(keys) == 0 {
+		return []string{missingLabel}
+	}
+
+	return keys
+}
+
+// bucket collects conversations per group, returning the groups sorted.
+func bucket[T any](convs []T, opts Options, conv func(T) api.Conversation) ([]string, map[string][]T) {
+	groups := make(map[string][]T)
+
+	for _, c := range convs {
+		for _, key := range opts.groups(conv(c)) {
+			groups[key] = append(groups[key], c)
+		}
+	}
+
+	keys := make([]string, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	return keys, groups
+}
+
+// Stats summarizes a set of values.
+type Stats struct {
+	Count       int                `json:"count"`
+	Avg         float64            `json:"avg"`
+	Min         float64            `json:"min"`
+	Max         float64            `json:"max"`
+	Percentiles map[string]float64 `json:"percentiles,omitempty"`
+}
+
+// Percentile returns the pth percentile, or 0 when it was not computed.
+func (s Stats) Percentile(p int) float64 {
+	return s.Percentiles["p"+strconv.Itoa(p)]
+}
+
+// Summarize computes count, mean, extremes and nearest-rank percentiles.
+func Summarize(values []float64, percentiles []int) Stats {
+	if len(values) == 0 {
+		return Stats{}
+	}
+
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+
+	var sum float64
+	for _, v := range sorted {
+		sum += v
+	}
+
+	s := Stats{
+		Count: len(sorted),
+		Avg:   sum / float64(len(sorted)),
+		Min:   sorted[0],
+		Max:   sorted[len(sorted)-1],
+	}
+
+	if len(percentiles) > 0 {
+		s.Percentiles = make(map[string]float64, len(percentiles))
+
+		for _, p := range percentiles {
+			rank := int(math.Ceil(float64(p) / 100 * float64(len(sorted))))
+			rank = min(max(rank, 1), len(sorted))
+			s.Percentiles["p"+strconv.Itoa(p)] = sorted[rank-1]
+		}
+	}
+
+	return s
+}
+
+// ResponseTimes is one row of the response-times report. Times are in
+// seconds.
+type ResponseTimes struct {
+	Group         string `json:"group"`
+	Conversations int    `json:"conversations"`
+	Responded     int    `json:"responded"`
+	Resolved      int    `json:"resolved"`
+	FirstResponse Stats  `json:"first_response"`
+	Resolution    Stats  `json:"resolution"`
+	Messages      Stats  `json:"messages_per_conversation"`
+}
+
+// ComputeResponseTimes measures, per group:
+//   - first response: from the first inbound message to the first outbound
+//     message after it, for conversations that got one;
+//   - resolution: from creation to the last message, for archived
+//     conversations;
+//   - messages per conversation.
+func ComputeResponseTimes(convs []Conversation, opts Options) ([]ResponseTimes, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
+	keys, groups := bucket(convs, opts, func(c Conversation) api.Conversation { return c.Conversation })
+	rows := make([]ResponseTimes, 0, len(keys))
+
+	for _, key := range keys {
+		var frt, resolution, counts []float64
+
+		for _, c := range groups[key] {
+			counts = append(counts, float64(len(c.Messages)))
+
+			if t, ok := firstResponse(c.Messages); ok {
+				frt = append(frt, t)
+			}
+
+			if c.Status == "archived" && len(c.Messages) > 0 {
+				resolution = append(resolution, max(lastMessageAt(c.Messages)-c.CreatedAt, 0))
+			}
+		}
+
+		rows = append(rows, ResponseTimes{
+			Group:         key,
+			Conversations: len(groups[key]),
+			Responded:     len(frt),
+			Resolved:      len(resolution),
+			FirstResponse: Summarize(frt, opts.Percentiles),
+			Resolution:    Summarize(resolution, opts.Percentiles),
+			Messages:      Summarize(counts, opts.Percentiles),
+		})
+	}
+
+	return rows, nil
+}
+
+func firstResponse(msgs []api.Message) (float64, bool) {
+	sorted := slices.Clone(msgs)
+	slices.SortStableFunc(sorted, func(a, b api.Message) int { return cmp.Compare(a.CreatedAt, b.CreatedAt) })
+
+	var inbound float64
+
+	for _, m := range sorted {
+		switch {
+		case m.IsInbound && inbound == 0:
+			inbound = m.CreatedAt
+		case !m.IsInbound && inbound > 0:
+			return m.CreatedAt - inbound, true
+		}
+	}
+
+	return 0, false
+}
+
+func lastMessageAt(msgs []api.Message) float64 {
+	var last float64
+	for _, m := range msgs {
+		last = max(last, m.CreatedAt)
+	}
+
+	return last
+}
+
+// Range bounds a report in Unix seconds; zero leaves a side open.
+type Range struct {
+	After  float64
+	Before float64
+}
+
+// Contains reports whether ts falls in the range.
+func (r Range) Contains(ts float64) bool {
+	return (r.After == 0 || ts >= r.After) && (r.Before == 0 || ts < r.Before)
+}
+
+// Volume is one row of the volume report.
+type Volume struct {
+	Group         string  `json:"group"`
+	Conversations int     `json:"conversations"`
+	Inbound       int     `json:"inbound_messages"`
+	Outbound      int     `json:"outbound_messages"`
+	PerConv       float64 `json:"messages_per_conversation"`
+}
+
+// ComputeVolume counts conversations created and messages sent or
+// received within rng, per group.
+func ComputeVolume(convs []Conversation, rng Range, opts Options) ([]Volume, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
+	keys, groups := bucket(convs, opts, func(c Conversation) api.Conversation { return c.Conversation })
+	rows := make([]Volume, 0, len(keys))
+
+	for _, key := range keys {
+		row := Volume{Group: key}
+
+		for _, c := range groups[key] {
+			if rng.Contains(c.CreatedAt) {
+				row.Conversations++
+			}
+
+			for _, m := range c.Messages {
+				if !rng.Contains(m.CreatedAt) {
+					continue
+				}
+
+				if m.IsInbound {
+					row.Inbound++
+				} else {
+					row.Outbound++
+				}
+			}
+		}
+
+		if row.Conversations > 0 {
+			row.PerConv = float64(row.Inbound+row.Outbound) / float64(row.Conversations)
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// Backlog is one row of the backlog report. Ages are in seconds.
+type Backlog struct {
+	Group      string `json:"group"`
+	Open       int    `json:"open"`
+	Unassigned int    `json:"unassigned"`
+	Waiting    int    `json:"waiting"`
+	Age        Stats  `json:"age"`
+	Oldest     string `json:"oldest_conversation_id,omitempty"`
+}
+
+// ComputeBacklog summarizes open conversations per group. A conversation's
+// age counts from WaitingSince when it is waiting on a reply, otherwise
+// from creation.
+func ComputeBacklog(convs []api.Conversation, now time.Time, opts Options) ([]Backlog, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
+	keys, groups := bucket(convs, opts, func(c api.Conversation) api.Conversation { return c })
+	rows := make([]Backlog, 0, len(keys))
+	nowTS := float64(now.Unix())
+
+	for _, key := range keys {
+		row := Backlog{Group: key}
+
+		var (
+			ages   []float64
+			oldest float64
+		)
+
+		for _, c := range groups[key] {
+			row.Open++
+
+			if c.Assignee == nil {
+				row.Unassigned++
+			}
+
+			since := c.CreatedAt
+			if c.WaitingSince > 0 {
+				row.Waiting++
+				since = c.WaitingSince
+			}
+
+			age := max(nowTS-since, 0)
+			ages = append(ages, age)
+
+			if age > oldest || row.Oldest == "" {
+				oldest, row.Oldest = age, c.ID
+			}
+		}
+
+		row.Age = Summarize(ages, opts.Percentiles)
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// FormatDuration renders seconds compactly: "45s", "12m", "3h05m", "2d04h".
+func FormatDuration(seconds float64) string {
+	d := time.Duration(seconds * float64(time.Second))
+
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+	default:
+		return fmt.Sprintf("%dd%02dh", int(d.Hours())/24, int(d.Hours())%24)
+	}
+}
+
+// ParsePercentiles validates percentile values between 1 and 100.
+func ParsePercentiles(values []int) ([]int, error) {
+	out := make([]int, 0, len(values))
+
+	for _, p := range values {
+		if p < 1 || p > 100 {
+			return nil, fmt.Errorf("%w: %d", ErrPercentile, p)
+		}
+
+		if !slices.Contains(out, p) {
+			out = append(out, p)
+		}
+	}
+
+	slices.Sort(out)
+
+	return out, nil
+}
+
+func unixTime(ts float64) time.Time {
+	sec, frac := math.Modf(ts)
+
+	return time.Unix(int64(sec), int64(frac*1e9))
+}
@@ -0,0 +1,183 @@
+package report
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+)
+
+func TestSummarize(t *testing.T) {
+	s := Summarize([]float64{50, 10, 40, 20, 30}, []int{50, 90, 100})
+
+	if s.Count != 5 || s.Avg != 30 || s.Min != 10 || s.Max != 50 {
+		t.Fatalf("unexpected stats: %+v", s)
+	}
+
+	for p, want := range map[int]float64{50: 30, 90: 50, 100: 50} {
+		if got := s.Percentile(p); got != want {
+			t.Errorf("p%d = %v, want %v", p, got, want)
+		}
+	}
+
+	if empty := Summarize(nil, []int{50}); empty.Count != 0 || empty.Percentiles != nil {
+		t.Fatalf("expected empty stats, got %+v", empty)
+	}
+}
+
+func msg(at float64, inbound bool) api.Message {
+	return api.Message{CreatedAt: at, IsInbound: inbound}
+}
+
+func TestComputeResponseTimes(t *testing.T) {
+	convs := []Conversation{
+		{
+			Conversation: api.Conversation{ID: "cnv_1", Status: "archived", CreatedAt: 1000, Tags: []api.Tag{{Name: "billing"}}},
+			Messages:     []api.Message{msg(1600, false), msg(1000, true), msg(1300, true)},
+		},
+		{
+			Conversation: api.Conversation{ID: "cnv_2", Status: "assigned", CreatedAt: 2000, Tags: []api.Tag{{Name: "billing"}, {Name: "vip"}}},
+			Messages:     []api.Message{msg(2000, true), msg(2060, false)},
+		},
+		{
+			// Outbound-only conversations have no first response.
+			Conversation: api.Conversation{ID: "cnv_3", Status: "archived", CreatedAt: 3000},
+			Messages:     []api.Message{msg(3000, false)},
+		},
+	}
+
+	rows, err := ComputeResponseTimes(convs, Options{GroupBy: GroupTag, Percentiles: []int{50}})
+	if err != nil {
+		t.Fatalf("ComputeResponseTimes: %v", err)
+	}
+
+	if len(rows) != 3 || rows[0].Group != "(none)" || rows[1].Group != "billing" || rows[2].Group != "vip" {
+		t.Fatalf("unexpected groups: %+v", rows)
+	}
+
+	billing := rows[1]
+	if billing.Conversations != 2 || billing.Responded != 2 || billing.Resolved != 1 {
+		t.Fatalf("unexpected billing counts: %+v", billing)
+	}
+
+	if billing.FirstResponse.Min != 60 || billing.FirstResponse.Max != 600 || billing.FirstResponse.Percentile(50) != 60 {
+		t.Fatalf("unexpected first response: %+v", billing.FirstResponse)
+	}
+
+	if billing.Resolution.Avg != 600 {
+		t.Fatalf("unexpected resolution: %+v", billing.Resolution)
+	}
+
+	if billing.Messages.Avg != 2.5 {
+		t.Fatalf("unexpected messages per conversation: %+v", billing.Messages)
+	}
+
+	if rows[0].Responded != 0 || rows[0].Resolved != 1 {
+		t.Fatalf("unexpected untagged row: %+v", rows[0])
+	}
+}
+
+func TestComputeVolume(t *testing.T) {
+	convs := []Conversation{
+		{
+			Conversation: api.Conversation{ID: "cnv_1", CreatedAt: 50, Assignee: &api.Teammate{Email: "a@example.com"}},
+			Messages:     []api.Message{msg(50, true), msg(150, false), msg(160, true)},
+		},
+		{
+			Conversation: api.Conversation{ID: "cnv_2", CreatedAt: 120},
+			Messages:     []api.Message{msg(120, true)},
+		},
+	}
+
+	rows, err := ComputeVolume(convs, Range{After: 100, Before: 200}, Options{GroupBy: GroupAssignee})
+	if err != nil {
+		t.Fatalf("ComputeVolume: %v", err)
+	}
+
+	want := []Volume{
+		{Group: "(none)", Conversations: 1, Inbound: 1, PerConv: 1},
+		{Group: "a@example.com", Conversations: 0, Inbound: 1, Outbound: 1},
+	}
+
+	if len(rows) != len(want) {
+		t.Fatalf("got %+v, want %+v", rows, want)
+	}
+
+	for i := range want {
+		if rows[i] != want[i] {
+			t.Errorf("row %d = %+v, want %+v", i, rows[i], want[i])
+		}
+	}
+}
+
+func TestComputeBacklog(t *testing.T) {
+	now := time.Unix(10_000, 0)
+	convs := []api.Conversation{
+		{ID: "cnv_1", CreatedAt: 1000, WaitingSince: 9000, Inboxes: []api.Inbox{{Name: "Support"}}},
+		{ID: "cnv_2", CreatedAt: 4000, Assignee: &api.Teammate{Email: "a@example.com"}, Inboxes: []api.Inbox{{Name: "Support"}}},
+	}
+
+	rows, err := ComputeBacklog(convs, now, Options{GroupBy: GroupInbox, Percentiles: []int{50}})
+	if err != nil {
+		t.Fatalf("ComputeBacklog: %v", err)
+	}
+
+	if len(rows) != 1 {
+		t.Fatalf("expected one group, got %+v", rows)
+	}
+
+	r := rows[0]
+	if r.Group != "Support" || r.Open != 2 || r.Unassigned != 1 || r.Waiting != 1 || r.Oldest != "cnv_2" {
+		t.Fatalf("unexpected row: %+v", r)
+	}
+
+	if r.Age.Min != 1000 || r.Age.Max != 6000 {
+		t.Fatalf("unexpected ages: %+v", r.Age)
+	}
+}
+
+func TestGroupByDayAndWeek(t *testing.T) {
+	created := float64(time.Date(2024, 12, 30, 12, 0, 0, 0, time.UTC).Unix())
+	conv := api.Conversation{CreatedAt: created}
+
+	if got := (Options{GroupBy: GroupDay, Location: time.UTC}).groups(conv); got[0] != "2024-12-30" {
+		t.Errorf("day = %v", got)
+	}
+
+	if got := (Options{GroupBy: GroupWeek, Location: time.UTC}).groups(conv); got[0] != "2025-W01" {
+		t.Errorf("week = %v", got)
+	}
+}
+
+func TestUnknownGroup(t *testing.T) {
+	if _, err := ComputeVolume(nil, Range{}, Options{GroupBy: "channel"}); !errors.Is(err, ErrUnknownGroup) {
+		t.Fatalf("expected ErrUnknownGroup, got %v", err)
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := map[float64]string{
+		42:               "42s",
+		125:              "2m",
+		3*3600 + 300:     "3h05m",
+		2*86400 + 4*3600: "2d04h",
+	}
+
+	for in, want := range tests {
+		if got := FormatDuration(in); got != want {
+			t.Errorf("FormatDuration(%v) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestParsePercentiles(t *testing.T) {
+	got, err := ParsePercentiles([]int{95, 50, 95})
+	if err != nil || len(got) != 2 || got[0] != 50 || got[1] != 95 {
+		t.Fatalf("ParsePercentiles = %v, %v", got, err)
+	}
+
+	if _, err := ParsePercentiles([]int{0}); !errors.Is(err, ErrPercentile) {
+		t.Fatalf("expected ErrPercentile, got %v", err)
+	}
+}
@@ -0,0 +1,90 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+)
+
+// ConversationFilter selects synced conversations. Zero values disable a
+// filter.
+type ConversationFilter struct {
+	// ActiveAfter keeps conversations with activity at or after this time.
+	ActiveAfter float64
+	// CreatedBefore keeps conversations created before this time.
+	CreatedBefore float64
+	// Open keeps conversations that are not archived, deleted or trashed.
+	Open bool
+}
+
+// Conversations returns the synced conversations matching f, most recently
+// active first.
+func (d *DB) Conversations(ctx context.Context, f ConversationFilter) ([]api.Conversation, error) {
+	var (
+		where []string
+		args  []any
+	)
+
+	if f.ActiveAfter > 0 {
+		where = append(where, "last_activity_at >= ?")
+		args = append(args, f.ActiveAfter)
+	}
+
+	if f.CreatedBefore > 0 {
+		where = append(where, "created_at < ?")
+		args = append(args, f.CreatedBefore)
+	}
+
+	if f.Open {
+		where = append(where, "status NOT IN ('archived', 'deleted', 'trashed', 'spam')")
+	}
+
+	query := "SELECT data FROM conversations"
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+
+	return decodeRows[api.Conversation](ctx, d, query+" ORDER BY last_activity_at DESC", args...)
+}
+
+// Messages returns the synced messages of a conversation, oldest first.
+func (d *DB) Messages(ctx context.Context, convID string) ([]api.Message, error) {
+	return decodeRows[api.Message](ctx, d, "SELECT data FROM messages WHERE conversation_id = ? ORDER BY created_at", convID)
+}
+
+// decodeRows runs a query selecting one data column and decodes each row.
+func decodeRows[T any](ctx context.Context, d *DB, query string, args ...any) ([]T, error) {
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+	defer rows.Close()
+
+	out := make([]T, 0)
+
+	for rows.Next() {
+		var (
+			data string
+			item T
+		)
+
+		if err := rows.Scan(&data); err != nil {
+			return nil, fmt.Errorf("query: %w", err)
+		}
+
+		if err := json.Unmarshal([]byte(data), &item); err != nil {
+			return nil, fmt.Errorf("decode row: %w", err)
+		}
+
+		out = append(out, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,443 @@
+package store
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+	"github.com/dedene/frontapp-cli/internal/markdown"
+)
+
+// ErrInvalidQuery is returned when the full-text query cannot be parsed.
+var ErrInvalidQuery = errors.New("invalid search query")
+
+// Kinds of indexed documents.
+const (
+	docConversation = "conversation"
+	docMessage      = "message"
+	docComment      = "comment"
+)
+
+// columnWeights are the bm25 weights of the search table's columns, in
+// order: hits in the subject rank above the body, handles between the two.
+const columnWeights = "3.0, 1.0, 2.0"
+
+// searchDoc is one row of the full-text index.
+type searchDoc struct {
+	Kind           string
+	RefID          string
+	ConversationID string
+	At             float64
+	Subject        string
+	Body           string
+	Handles        []string
+}
+
+func conversationDoc(c api.Conversation) searchDoc {
+	doc := searchDoc{Kind: docConversation, RefID: c.ID, ConversationID: c.ID, At: c.CreatedAt, Subject: c.Subject}
+	if c.Recipient != nil {
+		doc.Handles = append(doc.Handles, c.Recipient.Handle)
+	}
+
+	return doc
+}
+
+func messageDoc(convID string, m api.Message) searchDoc {
+	body := m.Text
+	if m.Body != "" {
+		if text, err := markdown.ToText(m.Body); err == nil {
+			body = text
+		}
+	}
+
+	doc := searchDoc{Kind: docMessage, RefID: m.ID, ConversationID: convID, At: m.CreatedAt, Subject: m.Subject, Body: body}
+
+	for _, r := range m.Recipients {
+		doc.Handles = append(doc.Handles, r.Handle)
+	}
+
+	if email := authorEmail(m.Author); email != "" {
+		doc.Handles = append(doc.Handles, email)
+	}
+
+	return doc
+}
+
+func commentDoc(convID string, c api.Comment) searchDoc {
+	doc := searchDoc{Kind: docComment, RefID: c.ID, ConversationID: convID, At: c.PostedAt, Body: c.Body}
+	if email := authorEmail(c.Author); email != "" {
+		doc.Handles = append(doc.Handles, email)
+	}
+
+	return doc
+}
+
+// index writes docs to the full-text index, replacing earlier versions.
+// Handles that belong to a synced contact are indexed with the contact's
+// name, so searching for a person finds their conversations.
+func (d *DB) index(ctx context.Context, docs []searchDoc) error {
+	if len(docs) == 0 {
+		return nil
+	}
+
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("index: %w", err)
+	}
+
+	if err := indexTx(ctx, tx, docs); err != nil {
+		_ = tx.Rollback()
+
+		return fmt.Errorf("index: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("index: %w", err)
+	}
+
+	return nil
+}
+
+func indexTx(ctx context.Context, tx *sql.Tx, docs []searchDoc) error {
+	for _, doc := range docs {
+		handles := make([]string, 0, len(doc.Handles))
+
+		for _, h := range doc.Handles {
+			if h = strings.TrimSpace(h); h == "" {
+				continue
+			}
+
+			handles = append(handles, h)
+
+			var name sql.NullString
+
+			err := tx.QueryRowContext(ctx, "SELECT c.name FROM contact_handles h JOIN contacts c ON c.id = h.contact_id WHERE h.handle = ?", h).Scan(&name)
+			if err != nil && !errors.Is(err, sql.ErrNoRows) {
+				return err
+			}
+
+			if name.String != "" && !slices.Contains(handles, name.String) {
+				handles = append(handles, name.String)
+			}
+		}
+
+		var docid int64
+
+		err := tx.QueryRowContext(ctx, "SELECT docid FROM search_docs WHERE kind = ? AND ref_id = ?", doc.Kind, doc.RefID).Scan(&docid)
+
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			res, err := tx.ExecContext(ctx, "INSERT INTO search_docs (kind, ref_id, conversation_id, at, subject, body, handles) VALUES (?, ?, ?, ?, ?, ?, ?)",
+				doc.Kind, doc.RefID, doc.ConversationID, doc.At, doc.Subject, doc.Body, strings.Join(handles, " "))
+			if err != nil {
+				return err
+			}
+
+			if docid, err = res.LastInsertId(); err != nil {
+				return err
+			}
+		case err != nil:
+			return err
+		default:
+			// An external-content FTS5 table unindexes a row from its
+			// old text, so delete it before search_docs changes.
+			_, err := tx.ExecContext(ctx, "INSERT INTO search (search, rowid, subject, body, handles) SELECT 'delete', docid, subject, body, handles FROM search_docs WHERE docid = ?", docid)
+			if err != nil {
+				return err
+			}
+
+			_, err = tx.ExecContext(ctx, "UPDATE search_docs SET conversation_id = ?, at = ?, subject = ?, body = ?, handles = ? WHERE docid = ?",
+				doc.ConversationID, doc.At, doc.Subject, doc.Body, strings.Join(handles, " "), docid)
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err = tx.ExecContext(ctx, "INSERT INTO search (rowid, subject, body, handles) SELECT docid, subject, body, handles FROM search_docs WHERE docid = ?", docid)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// putContactHandles maps every handle of contacts to its contact.
+func (d *DB) putContactHandles(ctx context.Context, contacts []api.Contact) error {
+	var rows [][]any
+
+	for _, c := range contacts {
+		for _, h := range c.Handles {
+			rows = append(rows, []any{h.Handle, c.ID})
+		}
+	}
+
+	return d.upsert(ctx, "contact_handles", []string{"handle", "contact_id"}, rows)
+}
+
+// reindex rebuilds contact_handles and the full-text index from the raw
+// API objects already in the database.
+func (d *DB) reindex(ctx context.Context) error {
+	if err := reindexTable(ctx, d, "contacts", func(_ []string, contacts []api.Contact) error {
+		return d.putContactHandles(ctx, contacts)
+	}); err != nil {
+		return err
+	}
+
+	if err := reindexTable(ctx, d, "conversations", func(_ []string, convs []api.Conversation) error {
+		docs := make([]searchDoc, len(convs))
+		for i, c := range convs {
+			docs[i] = conversationDoc(c)
+		}
+
+		return d.index(ctx, docs)
+	}); err != nil {
+		return err
+	}
+
+	if err := reindexTable(ctx, d, "messages", func(convIDs []string, msgs []api.Message) error {
+		docs := make([]searchDoc, len(msgs))
+		for i, m := range msgs {
+			docs[i] = messageDoc(convIDs[i], m)
+		}
+
+		return d.index(ctx, docs)
+	}); err != nil {
+		return err
+	}
+
+	return reindexTable(ctx, d, "comments", func(convIDs []string, comments []api.Comment) error {
+		docs := make([]searchDoc, len(comments))
+		for i, c := range comments {
+			docs[i] = commentDoc(convIDs[i], c)
+		}
+
+		return d.index(ctx, docs)
+	})
+}
+
+// reindexTable calls fn with every row of table in batches: the rows'
+// conversation_id (empty for tables without one) and decoded data.
+func reindexTable[T any](ctx context.Context, d *DB, table string, fn func([]string, []T) error) error {
+	convCol := "conversation_id"
+	if table == "contacts" || table == "conversations" {
+		convCol = "''"
+	}
+
+	const batch = 500
+
+	var last int64
+
+	for {
+		rows, err := d.db.QueryContext(ctx, fmt.Sprintf("SELECT rowid, %s, data FROM %s WHERE rowid > ? ORDER BY rowid LIMIT %d", convCol, table, batch), last)
+		if err != nil {
+			return fmt.Errorf("reindex %s: %w", table, err)
+		}
+
+		var (
+			convIDs []string
+			items   []T
+		)
+
+		for rows.Next() {
+			var (
+				convID, data string
+				item         T
+			)
+
+			if err := rows.Scan(&last, &convID, &data); err != nil {
+				rows.Close()
+
+				return fmt.Errorf("reindex %s: %w", table, err)
+			}
+
+			if err := json.Unmarshal([]byte(data), &item); err != nil {
+				rows.Close()
+
+				return fmt.Errorf("reindex %s: %w", table, err)
+			}
+
+			convIDs = append(convIDs, convID)
+			items = append(items, item)
+		}
+
+		rows.Close()
+
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("reindex %s: %w", table, err)
+		}
+
+		if len(items) > 0 {
+			if err := fn(convIDs, items); err != nil {
+				return err
+			}
+		}
+
+		if len(items) < batch {
+			return nil
+		}
+	}
+}
+
+// SearchOptions filters an offline search. Zero values disable a filter.
+type SearchOptions struct {
+	// Query uses SQLite FTS syntax: words, "phrases", prefix*, AND, OR,
+	// NOT and parentheses.
+	Query string
+	// From and To match sender and recipient handles (substring).
+	From string
+	To   string
+	// Tags must all be on the conversation, by ID or name.
+	Tags []string
+	// Before and After bound the time of the matching message, comment or
+	// conversation, as Unix seconds.
+	Before float64
+	After  float64
+	Limit  int
+}
+
+// SearchHit is a conversation matched by Search.
+type SearchHit struct {
+	Conversation   api.Conversation
+	Score          float64
+	LastActivityAt float64
+}
+
+// Search runs a ranked full-text search over synced conversations. Hits
+// are ordered by relevance, then by latest activity.
+func (d *DB) Search(ctx context.Context, opts SearchOptions) ([]SearchHit, error) {
+	var (
+		where []string
+		args  []any
+	)
+
+	query := strings.TrimSpace(opts.Query)
+	score := "NULL"
+	from := "search_docs d"
+
+	if query != "" {
+		// bm25 is lower for better matches.
+		from = "search JOIN search_docs d ON d.docid = search.rowid"
+		score = "-bm25(search, " + columnWeights + ")"
+
+		where = append(where, "search MATCH ?")
+		args = append(args, query)
+	}
+
+	if opts.Before > 0 {
+		where = append(where, "d.at < ?")
+		args = append(args, opts.Before)
+	}
+
+	if opts.After > 0 {
+		where = append(where, "d.at >= ?")
+		args = append(args, opts.After)
+	}
+
+	if opts.From != "" {
+		where = append(where, recipientFilter("'from'"))
+		args = append(args, "%"+opts.From+"%")
+	}
+
+	if opts.To != "" {
+		where = append(where, recipientFilter("'to', 'cc', 'bcc'"))
+		args = append(args, "%"+opts.To+"%")
+	}
+
+	for _, tag := range opts.Tags {
+		where = append(where, `EXISTS (SELECT 1 FROM json_each(c.data, '$.tags') t
+			WHERE json_extract(t.value, '$.id') = ? OR json_extract(t.value, '$.name') = ? COLLATE NOCASE)`)
+		args = append(args, tag, tag)
+	}
+
+	stmt := fmt.Sprintf("SELECT c.id, c.data, c.last_activity_at, %s FROM %s JOIN conversations c ON c.id = d.conversation_id", score, from)
+	if len(where) > 0 {
+		stmt += " WHERE " + strings.Join(where, " AND ")
+	}
+
+	rows, err := d.db.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, searchError(query, err)
+	}
+	defer rows.Close()
+
+	hits := make(map[string]*SearchHit)
+
+	for rows.Next() {
+		var (
+			id, data string
+			activity sql.NullFloat64
+			score    sql.NullFloat64
+		)
+
+		if err := rows.Scan(&id, &data, &activity, &score); err != nil {
+			return nil, fmt.Errorf("search: %w", err)
+		}
+
+		hit, ok := hits[id]
+		if !ok {
+			hit = &SearchHit{LastActivityAt: activity.Float64}
+			if err := json.Unmarshal([]byte(data), &hit.Conversation); err != nil {
+				return nil, fmt.Errorf("search: decode %s: %w", id, err)
+			}
+
+			hits[id] = hit
+		}
+
+		hit.Score += score.Float64
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, searchError(query, err)
+	}
+
+	out := make([]SearchHit, 0, len(hits))
+	for _, hit := range hits {
+		out = append(out, *hit)
+	}
+
+	slices.SortFunc(out, func(a, b SearchHit) int {
+		if c := cmp.Compare(b.Score, a.Score); c != 0 {
+			return c
+		}
+
+		if c := cmp.Compare(b.LastActivityAt, a.LastActivityAt); c != 0 {
+			return c
+		}
+
+		return cmp.Compare(a.Conversation.ID, b.Conversation.ID)
+	})
+
+	if opts.Limit > 0 && len(out) > opts.Limit {
+		out = out[:opts.Limit]
+	}
+
+	return out, nil
+}
+
+// ftsQueryErrors are the messages SQLite's FTS5 query parser fails with.
+var ftsQueryErrors = []string{"fts5: syntax error", "unterminated string", "no such column"}
+
+// searchError reports FTS query errors, including unknown "column:"
+// filters, as ErrInvalidQuery.
+func searchError(query string, err error) error {
+	if slices.ContainsFunc(ftsQueryErrors, func(s string) bool { return strings.Contains(err.Error(), s) }) {
+		return fmt.Errorf("%w: %s", ErrInvalidQuery, query)
+	}
+
+	return fmt.Errorf("search: %w", err)
+}
+
+// recipientFilter matches conversations with a message recipient in one of
+// roles (a quoted SQL list) whose handle is LIKE the bound argument.
+func recipientFilter(roles string) string {
+	return `EXISTS (SELECT 1 FROM messages m, json_each(m.data, '$.recipients') r
+		WHERE m.conversation_id = c.id AND json_extract(r.value, '$.role') IN (` + roles + `)
+		AND json_extract(r.value, '$.handle') LIKE ?)`
+}
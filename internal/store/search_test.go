@@ -0,0 +1,162 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+)
+
+func seedSearch(t *testing.T, db *DB) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	if err := db.PutContacts(ctx, []api.Contact{
+		{ID: "crd_1", Name: "Jane Customer", Handles: []api.Handle{{Handle: "jane@example.com", Source: "email"}}},
+	}); err != nil {
+		t.Fatalf("PutContacts: %v", err)
+	}
+
+	convs := []api.Conversation{
+		{ID: "cnv_1", Subject: "Refund for invoice 42", Tags: []api.Tag{{ID: "tag_1", Name: "Billing"}}},
+		{ID: "cnv_2", Subject: "Question", Tags: []api.Tag{{ID: "tag_2", Name: "Sales"}}},
+		{ID: "cnv_3", Subject: "Shipping delay"},
+	}
+	if err := db.PutConversations(ctx, convs, map[string]float64{"cnv_1": 100, "cnv_2": 300, "cnv_3": 200}); err != nil {
+		t.Fatalf("PutConversations: %v", err)
+	}
+
+	msgs := map[string][]api.Message{
+		"cnv_1": {{
+			ID: "msg_1", CreatedAt: 100, Body: "<p>Please send a <b>refund</b> for the invoice.</p>",
+			Recipients: []api.Recipient{{Handle: "jane@example.com", Role: "from"}, {Handle: "support@acme.test", Role: "to"}},
+		}},
+		"cnv_2": {{
+			ID: "msg_2", CreatedAt: 300, Body: "<p>Can I get a refund?</p>",
+			Recipients: []api.Recipient{{Handle: "bob@example.com", Role: "from"}, {Handle: "sales@acme.test", Role: "to"}},
+		}},
+		"cnv_3": {{
+			ID: "msg_3", CreatedAt: 200, Text: "Where is my parcel?",
+			Recipients: []api.Recipient{{Handle: "bob@example.com", Role: "from"}, {Handle: "support@acme.test", Role: "to"}},
+		}},
+	}
+	for convID, m := range msgs {
+		if err := db.PutMessages(ctx, convID, m); err != nil {
+			t.Fatalf("PutMessages: %v", err)
+		}
+	}
+
+	if err := db.PutComments(ctx, "cnv_3", []api.Comment{{ID: "com_1", PostedAt: 250, Body: "Carrier says invoice is missing"}}); err != nil {
+		t.Fatalf("PutComments: %v", err)
+	}
+}
+
+func searchIDs(t *testing.T, db *DB, opts SearchOptions) []string {
+	t.Helper()
+
+	hits, err := db.Search(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Search(%+v): %v", opts, err)
+	}
+
+	ids := make([]string, len(hits))
+	for i, h := range hits {
+		ids[i] = h.Conversation.ID
+	}
+
+	return ids
+}
+
+func TestSearch(t *testing.T) {
+	db := openTestDB(t)
+	seedSearch(t, db)
+
+	tests := []struct {
+		name string
+		opts SearchOptions
+		want []string
+	}{
+		{"ranked by subject hit", SearchOptions{Query: "refund"}, []string{"cnv_1", "cnv_2"}},
+		{"boolean and across docs", SearchOptions{Query: "refund AND invoice"}, []string{"cnv_1"}},
+		{"matches comments", SearchOptions{Query: "carrier"}, []string{"cnv_3"}},
+		{"html is stripped", SearchOptions{Query: "b"}, []string{}},
+		{"matches contact name", SearchOptions{Query: "jane"}, []string{"cnv_1"}},
+		{"from filter", SearchOptions{Query: "refund", From: "bob@"}, []string{"cnv_2"}},
+		{"to filter", SearchOptions{To: "support@"}, []string{"cnv_3", "cnv_1"}},
+		{"tag by name", SearchOptions{Query: "refund", Tags: []string{"billing"}}, []string{"cnv_1"}},
+		{"tag by id", SearchOptions{Tags: []string{"tag_2"}}, []string{"cnv_2"}},
+		{"after", SearchOptions{Query: "refund", After: 200}, []string{"cnv_2"}},
+		// bm25 ranks the rarer "parcel" above "refund".
+		{"before", SearchOptions{Query: "refund OR parcel", Before: 250}, []string{"cnv_3", "cnv_1"}},
+		{"limit", SearchOptions{Query: "refund", Limit: 1}, []string{"cnv_1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := searchIDs(t, db, tt.opts)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("got %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestSearchReplacesUpdatedDocs(t *testing.T) {
+	db := openTestDB(t)
+	seedSearch(t, db)
+
+	err := db.PutConversations(context.Background(), []api.Conversation{{ID: "cnv_3", Subject: "Lost package"}}, nil)
+	if err != nil {
+		t.Fatalf("PutConversations: %v", err)
+	}
+
+	if got := searchIDs(t, db, SearchOptions{Query: "shipping"}); len(got) != 0 {
+		t.Fatalf("expected old subject to be unindexed, got %v", got)
+	}
+
+	if got := searchIDs(t, db, SearchOptions{Query: "package"}); len(got) != 1 || got[0] != "cnv_3" {
+		t.Fatalf("expected new subject to match, got %v", got)
+	}
+}
+
+func TestSearchInvalidQuery(t *testing.T) {
+	db := openTestDB(t)
+	seedSearch(t, db)
+
+	for _, query := range []string{`"unterminated`, "jane@example.com", "nosuchcolumn:x"} {
+		_, err := db.Search(context.Background(), SearchOptions{Query: query})
+		if !errors.Is(err, ErrInvalidQuery) {
+			t.Fatalf("%s: expected ErrInvalidQuery, got %v", query, err)
+		}
+	}
+}
+
+func TestReindex(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	seedSearch(t, db)
+
+	if _, err := db.db.ExecContext(ctx, "DELETE FROM search; DELETE FROM search_docs; DELETE FROM contact_handles"); err != nil {
+		t.Fatalf("clear index: %v", err)
+	}
+
+	if got := searchIDs(t, db, SearchOptions{Query: "refund"}); len(got) != 0 {
+		t.Fatalf("expected empty index, got %v", got)
+	}
+
+	if err := db.reindex(ctx); err != nil {
+		t.Fatalf("reindex: %v", err)
+	}
+
+	if got := searchIDs(t, db, SearchOptions{Query: "jane"}); len(got) != 1 || got[0] != "cnv_1" {
+		t.Fatalf("expected reindexed contact name to match, got %v", got)
+	}
+}
@@ -0,0 +1,540 @@
+// Package store mirrors Front data into a local SQLite database so it can be
+// queried offline.
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	_ "modernc.org/sqlite" // pure-Go SQLite driver, so builds don't need cgo
+
+	"github.com/dedene/frontapp-cli/internal/api"
+)
+
+// ErrNotSynced is returned when opening a database that does not exist yet.
+var ErrNotSynced = errors.New("no local database yet: run 'frontcli sync' first")
+
+// ErrOutdated is returned when opening a database read-only whose schema is
+// older than this frontcli expects.
+var ErrOutdated = errors.New("local database schema is out of date: run 'frontcli sync' to upgrade the local database")
+
+// schemaVersion is stored in PRAGMA user_version. Bump it together with
+// a new entry in migrations.
+const schemaVersion = 2
+
+var migrations = []string{
+	// 1: initial schema. Every table keeps the raw API object in data so
+	// new columns can be backfilled without refetching.
+	`
+CREATE TABLE conversations (
+	id               TEXT PRIMARY KEY,
+	subject          TEXT,
+	status           TEXT,
+	assignee_id      TEXT,
+	assignee_email   TEXT,
+	tags             TEXT,
+	inbox_ids        TEXT,
+	created_at       REAL,
+	waiting_since    REAL,
+	last_activity_at REAL,
+	data             TEXT NOT NULL
+);
+CREATE INDEX conversations_activity ON conversations(last_activity_at);
+
+CREATE TABLE messages (
+	id              TEXT PRIMARY KEY,
+	conversation_id TEXT NOT NULL,
+	type            TEXT,
+	is_inbound      INTEGER,
+	author_email    TEXT,
+	subject         TEXT,
+	blurb           TEXT,
+	text            TEXT,
+	body            TEXT,
+	created_at      REAL,
+	data            TEXT NOT NULL
+);
+CREATE INDEX messages_conversation ON messages(conversation_id, created_at);
+
+CREATE TABLE comments (
+	id              TEXT PRIMARY KEY,
+	conversation_id TEXT NOT NULL,
+	author_email    TEXT,
+	body            TEXT,
+	posted_at       REAL,
+	data            TEXT NOT NULL
+);
+CREATE INDEX comments_conversation ON comments(conversation_id, posted_at);
+
+CREATE TABLE contacts (
+	id         TEXT PRIMARY KEY,
+	name       TEXT,
+	handles    TEXT,
+	created_at REAL,
+	updated_at REAL,
+	data       TEXT NOT NULL
+);
+
+CREATE TABLE tags (
+	id            TEXT PRIMARY KEY,
+	name          TEXT,
+	parent_tag_id TEXT,
+	data          TEXT NOT NULL
+);
+
+CREATE TABLE inboxes (
+	id   TEXT PRIMARY KEY,
+	name TEXT,
+	data TEXT NOT NULL
+);
+
+CREATE TABLE teammates (
+	id         TEXT PRIMARY KEY,
+	email      TEXT,
+	username   TEXT,
+	first_name TEXT,
+	last_name  TEXT,
+	data       TEXT NOT NULL
+);
+
+CREATE TABLE sync_state (
+	key   TEXT PRIMARY KEY,
+	value TEXT NOT NULL
+);
+`,
+	// 2: full-text search. search_docs holds one row per conversation,
+	// message and comment; search is an FTS5 index over it.
+	`
+CREATE TABLE search_docs (
+	docid           INTEGER PRIMARY KEY,
+	kind            TEXT NOT NULL,
+	ref_id          TEXT NOT NULL,
+	conversation_id TEXT NOT NULL,
+	at              REAL,
+	subject         TEXT,
+	body            TEXT,
+	handles         TEXT,
+	UNIQUE (kind, ref_id)
+);
+CREATE INDEX search_docs_conversation ON search_docs(conversation_id);
+
+CREATE TABLE contact_handles (
+	handle     TEXT PRIMARY KEY COLLATE NOCASE,
+	contact_id TEXT NOT NULL
+);
+
+CREATE VIRTUAL TABLE search USING fts5(subject, body, handles, content='search_docs', content_rowid='docid', tokenize='unicode61');
+`,
+}
+
+// DB is an open local mirror.
+type DB struct {
+	db   *sql.DB
+	path string
+}
+
+// Path returns the database file for an account under base.
+func Path(base, account string) string {
+	account = strings.ToLower(strings.TrimSpace(account))
+	if account == "" {
+		account = "default"
+	}
+
+	return filepath.Join(base, url.PathEscape(account)+".db")
+}
+
+// Open opens (creating if needed) the database at path and migrates it to
+// the current schema.
+func Open(ctx context.Context, path string) (*DB, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("create database dir: %w", err)
+	}
+
+	db, err := sql.Open("sqlite", "file:"+path+"?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)&_pragma=foreign_keys(1)")
+	if err != nil {
+		return nil, fmt.Errorf("open database: %w", err)
+	}
+
+	d := &DB{db: db, path: path}
+	if err := d.migrate(ctx); err != nil {
+		db.Close()
+
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// OpenReadOnly opens an existing database for queries only.
+func OpenReadOnly(ctx context.Context, path string) (*DB, error) {
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotSynced
+	}
+
+	db, err := sql.Open("sqlite", "file:"+path+"?mode=ro&_pragma=busy_timeout(5000)&_pragma=query_only(1)")
+	if err != nil {
+		return nil, fmt.Errorf("open database: %w", err)
+	}
+
+	// A read-only connection cannot migrate, so an old schema is reported
+	// instead of surfacing as missing tables or columns later.
+	var version int
+	if err := db.QueryRowContext(ctx, "PRAGMA user_version").Scan(&version); err != nil {
+		db.Close()
+
+		return nil, fmt.Errorf("open database: %w", err)
+	}
+
+	if version < schemaVersion {
+		db.Close()
+
+		return nil, fmt.Errorf("%w (schema version %d, want %d)", ErrOutdated, version, schemaVersion)
+	}
+
+	return &DB{db: db, path: path}, nil
+}
+
+// Close closes the database.
+func (d *DB) Close() error {
+	if err := d.db.Close(); err != nil {
+		return fmt.Errorf("close database: %w", err)
+	}
+
+	return nil
+}
+
+func (d *DB) migrate(ctx context.Context) error {
+	var version int
+	if err := d.db.QueryRowContext(ctx, "PRAGMA user_version").Scan(&version); err != nil {
+		return fmt.Errorf("read schema version: %w", err)
+	}
+
+	if version > schemaVersion {
+		return fmt.Errorf("database %s has schema version %d, newer than this frontcli supports (%d)", d.path, version, schemaVersion)
+	}
+
+	for v := version; v < schemaVersion; v++ {
+		tx, err := d.db.BeginTx(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("migrate database: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, migrations[v]); err != nil {
+			_ = tx.Rollback()
+
+			return fmt.Errorf("migrate database to version %d: %w", v+1, err)
+		}
+
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf("PRAGMA user_version = %d", v+1)); err != nil {
+			_ = tx.Rollback()
+
+			return fmt.Errorf("migrate database to version %d: %w", v+1, err)
+		}
+
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("migrate database to version %d: %w", v+1, err)
+		}
+	}
+
+	// Databases synced before the search index existed are indexed from
+	// the stored API objects.
+	if version > 0 && version < 2 {
+		return d.reindex(ctx)
+	}
+
+	return nil
+}
+
+// State returns a sync_state value, or "" when unset.
+func (d *DB) State(ctx context.Context, key string) (string, error) {
+	var value string
+
+	err := d.db.QueryRowContext(ctx, "SELECT value FROM sync_state WHERE key = ?", key).Scan(&value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("read sync state %s: %w", key, err)
+	}
+
+	return value, nil
+}
+
+// SetState stores a sync_state value; an empty value deletes the key.
+func (d *DB) SetState(ctx context.Context, key, value string) error {
+	var err error
+
+	if value == "" {
+		_, err = d.db.ExecContext(ctx, "DELETE FROM sync_state WHERE key = ?", key)
+	} else {
+		_, err = d.db.ExecContext(ctx, "INSERT INTO sync_state (key, value) VALUES (?, ?) ON CONFLICT(key) DO UPDATE SET value = excluded.value", key, value)
+	}
+
+	if err != nil {
+		return fmt.Errorf("write sync state %s: %w", key, err)
+	}
+
+	return nil
+}
+
+// upsert writes rows into table inside one transaction. Each row holds the
+// values for columns, in order.
+func (d *DB) upsert(ctx context.Context, table string, columns []string, rows [][]any) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	updates := make([]string, 0, len(columns)-1)
+	for _, col := range columns[1:] {
+		updates = append(updates, col+" = excluded."+col)
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s) ON CONFLICT(%s) DO UPDATE SET %s",
+		table, strings.Join(columns, ", "), strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", "),
+		columns[0], strings.Join(updates, ", "))
+
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("write %s: %w", table, err)
+	}
+
+	stmt, err := tx.PrepareContext(ctx, query)
+	if err != nil {
+		_ = tx.Rollback()
+
+		return fmt.Errorf("write %s: %w", table, err)
+	}
+
+	defer stmt.Close()
+
+	for _, row := range rows {
+		if _, err := stmt.ExecContext(ctx, row...); err != nil {
+			_ = tx.Rollback()
+
+			return fmt.Errorf("write %s: %w", table, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("write %s: %w", table, err)
+	}
+
+	return nil
+}
+
+func rawJSON(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "{}"
+	}
+
+	return string(data)
+}
+
+func authorEmail(a *api.Author) string {
+	if a == nil {
+		return ""
+	}
+
+	if a.Email != "" {
+		return a.Email
+	}
+
+	return a.Username
+}
+
+// PutConversations upserts conversations. lastActivity maps conversation
+// IDs to the newest message or comment time seen, when known.
+func (d *DB) PutConversations(ctx context.Context, convs []api.Conversation, lastActivity map[string]float64) error {
+	rows := make([][]any, 0, len(convs))
+
+	for _, c := range convs {
+		var assigneeID, assigneeEmail string
+		if c.Assignee != nil {
+			assigneeID, assigneeEmail = c.Assignee.ID, c.Assignee.Email
+		}
+
+		tags := make([]string, len(c.Tags))
+		for i, t := range c.Tags {
+			tags[i] = t.Name
+		}
+
+		inboxes := make([]string, len(c.Inboxes))
+		for i, inbox := range c.Inboxes {
+			inboxes[i] = inbox.ID
+		}
+
+		rows = append(rows, []any{
+			c.ID, c.Subject, c.Status, assigneeID, assigneeEmail,
+			strings.Join(tags, ","), strings.Join(inboxes, ","),
+			c.CreatedAt, c.WaitingSince, lastActivity[c.ID], rawJSON(c),
+		})
+	}
+
+	err := d.upsert(ctx, "conversations", []string{
+		"id", "subject", "status", "assignee_id", "assignee_email", "tags", "inbox_ids",
+		"created_at", "waiting_since", "last_activity_at", "data",
+	}, rows)
+	if err != nil {
+		return err
+	}
+
+	docs := make([]searchDoc, len(convs))
+	for i, c := range convs {
+		docs[i] = conversationDoc(c)
+	}
+
+	return d.index(ctx, docs)
+}
+
+// PutMessages upserts the messages of a conversation.
+func (d *DB) PutMessages(ctx context.Context, convID string, msgs []api.Message) error {
+	rows := make([][]any, 0, len(msgs))
+	for _, m := range msgs {
+		rows = append(rows, []any{
+			m.ID, convID, m.Type, m.IsInbound, authorEmail(m.Author), m.Subject,
+			m.Blurb, m.Text, m.Body, m.CreatedAt, rawJSON(m),
+		})
+	}
+
+	err := d.upsert(ctx, "messages", []string{
+		"id", "conversation_id", "type", "is_inbound", "author_email", "subject",
+		"blurb", "text", "body", "created_at", "data",
+	}, rows)
+	if err != nil {
+		return err
+	}
+
+	docs := make([]searchDoc, len(msgs))
+	for i, m := range msgs {
+		docs[i] = messageDoc(convID, m)
+	}
+
+	return d.index(ctx, docs)
+}
+
+// PutComments upserts the comments of a conversation.
+func (d *DB) PutComments(ctx context.Context, convID string, comments []api.Comment) error {
+	rows := make([][]any, 0, len(comments))
+	for _, c := range comments {
+		rows = append(rows, []any{c.ID, convID, authorEmail(c.Author), c.Body, c.PostedAt, rawJSON(c)})
+	}
+
+	if err := d.upsert(ctx, "comments", []string{"id", "conversation_id", "author_email", "body", "posted_at", "data"}, rows); err != nil {
+		return err
+	}
+
+	docs := make([]searchDoc, len(comments))
+	for i, c := range comments {
+		docs[i] = commentDoc(convID, c)
+	}
+
+	return d.index(ctx, docs)
+}
+
+// PutContacts upserts contacts.
+func (d *DB) PutContacts(ctx context.Context, contacts []api.Contact) error {
+	rows := make([][]any, 0, len(contacts))
+
+	for _, c := range contacts {
+		handles := make([]string, len(c.Handles))
+		for i, h := range c.Handles {
+			handles[i] = h.Handle
+		}
+
+		rows = append(rows, []any{c.ID, c.Name, strings.Join(handles, ","), c.CreatedAt, c.UpdatedAt, rawJSON(c)})
+	}
+
+	if err := d.upsert(ctx, "contacts", []string{"id", "name", "handles", "created_at", "updated_at", "data"}, rows); err != nil {
+		return err
+	}
+
+	return d.putContactHandles(ctx, contacts)
+}
+
+// PutTags upserts tags.
+func (d *DB) PutTags(ctx context.Context, tags []api.Tag) error {
+	rows := make([][]any, 0, len(tags))
+	for _, t := range tags {
+		rows = append(rows, []any{t.ID, t.Name, t.ParentTagID, rawJSON(t)})
+	}
+
+	return d.upsert(ctx, "tags", []string{"id", "name", "parent_tag_id", "data"}, rows)
+}
+
+// PutInboxes upserts inboxes.
+func (d *DB) PutInboxes(ctx context.Context, inboxes []api.Inbox) error {
+	rows := make([][]any, 0, len(inboxes))
+	for _, i := range inboxes {
+		rows = append(rows, []any{i.ID, i.Name, rawJSON(i)})
+	}
+
+	return d.upsert(ctx, "inboxes", []string{"id", "name", "data"}, rows)
+}
+
+// PutTeammates upserts teammates.
+func (d *DB) PutTeammates(ctx context.Context, teammates []api.Teammate) error {
+	rows := make([][]any, 0, len(teammates))
+	for _, t := range teammates {
+		rows = append(rows, []any{t.ID, t.Email, t.Username, t.FirstName, t.LastName, rawJSON(t)})
+	}
+
+	return d.upsert(ctx, "teammates", []string{"id", "email", "username", "first_name", "last_name", "data"}, rows)
+}
+
+// Result is the outcome of Query.
+type Result struct {
+	Columns []string
+	Rows    [][]any
+}
+
+// Query runs a read-only SQL statement.
+func (d *DB) Query(ctx context.Context, query string, args ...any) (*Result, error) {
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+
+	res := &Result{Columns: cols, Rows: [][]any{}}
+
+	for rows.Next() {
+		values := make([]any, len(cols))
+		ptrs := make([]any, len(cols))
+
+		for i := range values {
+			ptrs[i] = &values[i]
+		}
+
+		if err := rows.Scan(ptrs...); err != nil {
+			return nil, fmt.Errorf("query: %w", err)
+		}
+
+		for i, v := range values {
+			if b, ok := v.([]byte); ok {
+				values[i] = string(b)
+			}
+		}
+
+		res.Rows = append(res.Rows, values)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+
+	return res, nil
+}
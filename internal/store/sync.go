@@ -0,0 +1,285 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+)
+
+// Resources that can be synced, in the order Sync runs them.
+const (
+	ResourceTags          = "tags"
+	ResourceInboxes       = "inboxes"
+	ResourceTeammates     = "teammates"
+	ResourceContacts      = "contacts"
+	ResourceConversations = "conversations"
+)
+
+// Resources lists every resource Sync knows about.
+var Resources = []string{ResourceTags, ResourceInboxes, ResourceTeammates, ResourceContacts, ResourceConversations}
+
+// ErrUnknownResource is returned for a resource name not in Resources.
+var ErrUnknownResource = errors.New("unknown resource")
+
+// sync_state keys for the conversation walk.
+const (
+	stateSyncedUntil = "conversations.synced_until"
+	stateRunStarted  = "conversations.run_started"
+	statePageToken   = "conversations.page_token"
+)
+
+// overlap is subtracted from the watermark so activity that landed while
+// the previous run was walking the listing is picked up again.
+const overlap = 5 * time.Minute
+
+const pageSize = 100
+
+// staleRun is how many consecutive conversations with no activity since the
+// watermark end the walk. Front orders the listing by its own notion of
+// activity, so a single stale conversation can sit among fresh ones.
+const staleRun = 3
+
+// Stats counts the records written by a sync.
+type Stats struct {
+	Conversations int  `json:"conversations"`
+	Messages      int  `json:"messages"`
+	Comments      int  `json:"comments"`
+	Contacts      int  `json:"contacts"`
+	Tags          int  `json:"tags"`
+	Inboxes       int  `json:"inboxes"`
+	Teammates     int  `json:"teammates"`
+	Resumed       bool `json:"resumed"`
+	Incremental   bool `json:"incremental"`
+}
+
+// SyncOptions controls a sync run.
+type SyncOptions struct {
+	// Resources limits the sync to these resources; empty means all.
+	Resources []string
+	// Full ignores the watermark and walks every conversation.
+	Full bool
+	// Progress, if set, is called after every conversation page.
+	Progress func(Stats)
+	// Now returns the current time; defaults to time.Now.
+	Now func() time.Time
+}
+
+// Sync mirrors Front into db.
+//
+// Tags, inboxes, teammates and contacts are refetched in full. Conversations
+// are walked newest-activity first; a run of staleRun conversations whose
+// latest message or comment predates the previous run's start ends the walk,
+// since everything after them in the listing is older still. The page token is saved after each
+// page, so an interrupted run picks up where it stopped.
+func Sync(ctx context.Context, client *api.Client, db *DB, opts SyncOptions) (Stats, error) {
+	var stats Stats
+
+	for _, r := range opts.Resources {
+		if !slices.Contains(Resources, r) {
+			return stats, fmt.Errorf("%w: %s", ErrUnknownResource, r)
+		}
+	}
+
+	want := func(r string) bool { return len(opts.Resources) == 0 || slices.Contains(opts.Resources, r) }
+
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+
+	if want(ResourceTags) {
+		if err := syncAll(ctx, client, "/tags?limit="+strconv.Itoa(pageSize), db.PutTags, &stats.Tags); err != nil {
+			return stats, err
+		}
+	}
+
+	if want(ResourceInboxes) {
+		if err := syncAll(ctx, client, "/inboxes?limit="+strconv.Itoa(pageSize), db.PutInboxes, &stats.Inboxes); err != nil {
+			return stats, err
+		}
+	}
+
+	if want(ResourceTeammates) {
+		if err := syncAll(ctx, client, "/teammates?limit="+strconv.Itoa(pageSize), db.PutTeammates, &stats.Teammates); err != nil {
+			return stats, err
+		}
+	}
+
+	if want(ResourceContacts) {
+		if err := syncAll(ctx, client, "/contacts?limit="+strconv.Itoa(pageSize), db.PutContacts, &stats.Contacts); err != nil {
+			return stats, err
+		}
+	}
+
+	if want(ResourceConversations) {
+		if err := syncConversations(ctx, client, db, opts, &stats); err != nil {
+			return stats, err
+		}
+	}
+
+	return stats, nil
+}
+
+func syncAll[T any](ctx context.Context, client *api.Client, path string, put func(context.Context, []T) error, count *int) error {
+	_, err := api.Paginate(ctx, client, path, api.PageOptions{}, func(items []T) error {
+		if err := put(ctx, items); err != nil {
+			return err
+		}
+
+		*count += len(items)
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("sync %s: %w", path, err)
+	}
+
+	return nil
+}
+
+func syncConversations(ctx context.Context, client *api.Client, db *DB, opts SyncOptions, stats *Stats) error {
+	pageToken, err := db.State(ctx, statePageToken)
+	if err != nil {
+		return err
+	}
+
+	started, err := db.State(ctx, stateRunStarted)
+	if err != nil {
+		return err
+	}
+
+	if opts.Full || started == "" {
+		// Start a fresh walk. A resumed run keeps the original start time so
+		// the watermark still covers activity from before the interruption.
+		pageToken = ""
+		started = strconv.FormatInt(opts.Now().Unix(), 10)
+
+		if err := db.SetState(ctx, stateRunStarted, started); err != nil {
+			return err
+		}
+
+		if err := db.SetState(ctx, statePageToken, ""); err != nil {
+			return err
+		}
+	} else {
+		stats.Resumed = true
+	}
+
+	var watermark float64
+
+	if !opts.Full {
+		until, err := db.State(ctx, stateSyncedUntil)
+		if err != nil {
+			return err
+		}
+
+		if until != "" {
+			watermark, _ = strconv.ParseFloat(until, 64)
+			watermark -= overlap.Seconds()
+			stats.Incremental = true
+		}
+	}
+
+	listOpts := api.ListConversationsOptions{Limit: pageSize, SortBy: "date", SortOrder: "desc", PageToken: pageToken}
+
+	stale := 0
+
+	for {
+		resp, err := client.ListConversations(ctx, listOpts)
+		if err != nil {
+			return fmt.Errorf("sync conversations: %w", err)
+		}
+
+		done, err := syncConversationPage(ctx, client, db, resp.Results, watermark, &stale, stats)
+		if err != nil {
+			return err
+		}
+
+		next := api.PageToken(resp.Pagination.Next)
+		if done || resp.Pagination.Next == "" {
+			break
+		}
+
+		if err := db.SetState(ctx, statePageToken, next); err != nil {
+			return err
+		}
+
+		if opts.Progress != nil {
+			opts.Progress(*stats)
+		}
+
+		listOpts.PageToken = next
+	}
+
+	if err := db.SetState(ctx, stateSyncedUntil, started); err != nil {
+		return err
+	}
+
+	if err := db.SetState(ctx, statePageToken, ""); err != nil {
+		return err
+	}
+
+	return db.SetState(ctx, stateRunStarted, "")
+}
+
+// syncConversationPage stores a page of conversations with their messages
+// and comments. stale carries the count of consecutive conversations with no
+// activity since watermark across pages; it reports done once that count
+// reaches staleRun.
+func syncConversationPage(ctx context.Context, client *api.Client, db *DB, convs []api.Conversation, watermark float64, stale *int, stats *Stats) (bool, error) {
+	for i, conv := range convs {
+		latest := conv.CreatedAt
+
+		msgPath := fmt.Sprintf("/conversations/%s/messages?limit=%d", conv.ID, pageSize)
+
+		_, err := api.Paginate(ctx, client, msgPath, api.PageOptions{}, func(msgs []api.Message) error {
+			for _, m := range msgs {
+				latest = max(latest, m.CreatedAt)
+			}
+
+			stats.Messages += len(msgs)
+
+			return db.PutMessages(ctx, conv.ID, msgs)
+		})
+		if err != nil {
+			return false, fmt.Errorf("sync messages of %s: %w", conv.ID, err)
+		}
+
+		comPath := fmt.Sprintf("/conversations/%s/comments?limit=%d", conv.ID, pageSize)
+
+		_, err = api.Paginate(ctx, client, comPath, api.PageOptions{}, func(comments []api.Comment) error {
+			for _, c := range comments {
+				latest = max(latest, c.PostedAt)
+			}
+
+			stats.Comments += len(comments)
+
+			return db.PutComments(ctx, conv.ID, comments)
+		})
+		if err != nil {
+			return false, fmt.Errorf("sync comments of %s: %w", conv.ID, err)
+		}
+
+		if err := db.PutConversations(ctx, convs[i:i+1], map[string]float64{conv.ID: latest}); err != nil {
+			return false, err
+		}
+
+		stats.Conversations++
+
+		if watermark == 0 || latest >= watermark {
+			*stale = 0
+
+			continue
+		}
+
+		if *stale++; *stale >= staleRun {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,293 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+)
+
+// fakeFront serves conversations two per page, newest first. Conversation i
+// has one message at activity[i].
+type fakeFront struct {
+	srv        *httptest.Server
+	activity   []float64
+	listed     atomic.Int32
+	failPage   atomic.Int32
+	messageHit atomic.Int32
+}
+
+func newFakeFront(t *testing.T, activity []float64) *fakeFront {
+	t.Helper()
+
+	f := &fakeFront{activity: activity}
+	f.failPage.Store(-1)
+
+	f.srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.URL.Path == "/conversations":
+			if r.URL.Query().Get("sort_by") != "date" {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"_error":{"status":400,"title":"Bad request","message":"sort_by=date required"}}`))
+
+				return
+			}
+
+			page := 0
+			if token := r.URL.Query().Get("page_token"); token != "" {
+				_, _ = fmt.Sscanf(token, "p%d", &page)
+			}
+
+			if int(f.failPage.Load()) == page {
+				f.failPage.Store(-1)
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"_error":{"status":400,"title":"Bad request","message":"boom"}}`))
+
+				return
+			}
+
+			f.listed.Add(1)
+
+			var items []string
+
+			for i := page * 2; i < min(page*2+2, len(f.activity)); i++ {
+				items = append(items, fmt.Sprintf(`{"id":"cnv_%d","subject":"Subject %d","status":"open","created_at":%v}`, i, i, f.activity[i]-10))
+			}
+
+			next := ""
+			if (page+1)*2 < len(f.activity) {
+				next = fmt.Sprintf("%s/conversations?page_token=p%d", f.srv.URL, page+1)
+			}
+
+			_, _ = fmt.Fprintf(w, `{"_results":[%s],"_pagination":{"next":%q}}`, strings.Join(items, ","), next)
+		case strings.HasSuffix(r.URL.Path, "/messages"):
+			f.messageHit.Add(1)
+
+			var i int
+			_, _ = fmt.Sscanf(r.URL.Path, "/conversations/cnv_%d/messages", &i)
+			_, _ = fmt.Fprintf(w, `{"_results":[{"id":"msg_%d","type":"email","is_inbound":true,"created_at":%v,"text":"hello %d","author":{"email":"a@example.com"}}],"_pagination":{}}`, i, f.activity[i], i)
+		case strings.HasSuffix(r.URL.Path, "/comments"):
+			_, _ = w.Write([]byte(`{"_results":[],"_pagination":{}}`))
+		case r.URL.Path == "/tags":
+			_, _ = w.Write([]byte(`{"_results":[{"id":"tag_1","name":"billing"}],"_pagination":{}}`))
+		default:
+			_, _ = w.Write([]byte(`{"_results":[],"_pagination":{}}`))
+		}
+	}))
+	t.Cleanup(f.srv.Close)
+
+	return f
+}
+
+func (f *fakeFront) client() *api.Client {
+	return api.NewClientWithBaseURL(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}), f.srv.URL)
+}
+
+func openTestDB(t *testing.T) *DB {
+	t.Helper()
+
+	db, err := Open(context.Background(), filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+
+	t.Cleanup(func() { _ = db.Close() })
+
+	return db
+}
+
+func count(t *testing.T, db *DB, table string) int64 {
+	t.Helper()
+
+	res, err := db.Query(context.Background(), "SELECT COUNT(*) FROM "+table)
+	if err != nil {
+		t.Fatalf("count %s: %v", table, err)
+	}
+
+	return res.Rows[0][0].(int64)
+}
+
+func TestSyncFullThenIncremental(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(10_000, 0)
+	f := newFakeFront(t, []float64{9000, 8000, 7000, 6000, 5000})
+	db := openTestDB(t)
+
+	opts := SyncOptions{Now: func() time.Time { return now }}
+
+	stats, err := Sync(ctx, f.client(), db, opts)
+	if err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+
+	if stats.Conversations != 5 || stats.Messages != 5 || stats.Tags != 1 || stats.Incremental {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+
+	if got := count(t, db, "conversations"); got != 5 {
+		t.Fatalf("expected 5 conversations, got %d", got)
+	}
+
+	res, err := db.Query(ctx, "SELECT last_activity_at, author_email FROM conversations JOIN messages ON messages.conversation_id = conversations.id WHERE conversations.id = 'cnv_1'")
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+
+	if res.Rows[0][0] != float64(8000) || res.Rows[0][1] != "a@example.com" {
+		t.Fatalf("unexpected row: %v", res.Rows[0])
+	}
+
+	// New activity on the first conversation only: the next run must stop
+	// after staleRun stale conversations instead of walking the whole listing.
+	f.activity = append(f.activity, 4000, 3000)
+	f.activity[0] = 20_000
+	now = time.Unix(30_000, 0)
+	f.listed.Store(0)
+
+	stats, err = Sync(ctx, f.client(), db, SyncOptions{Resources: []string{ResourceConversations}, Now: opts.Now})
+	if err != nil {
+		t.Fatalf("incremental Sync: %v", err)
+	}
+
+	if !stats.Incremental || stats.Conversations != 1+staleRun || f.listed.Load() != 2 {
+		t.Fatalf("expected incremental run to stop after %d stale conversations, stats=%+v pages=%d", staleRun, stats, f.listed.Load())
+	}
+}
+
+func TestSyncToleratesOneStaleConversation(t *testing.T) {
+	ctx := context.Background()
+	f := newFakeFront(t, []float64{9000, 8000, 7000, 6000, 5000})
+	db := openTestDB(t)
+	opts := SyncOptions{Resources: []string{ResourceConversations}, Now: func() time.Time { return time.Unix(10_000, 0) }}
+
+	if _, err := Sync(ctx, f.client(), db, opts); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+
+	// cnv_1 is stale but sits between two conversations with new activity;
+	// the walk must keep going and pick up cnv_2.
+	f.activity[0] = 20_000
+	f.activity[2] = 19_000
+	opts.Now = func() time.Time { return time.Unix(30_000, 0) }
+
+	if _, err := Sync(ctx, f.client(), db, opts); err != nil {
+		t.Fatalf("incremental Sync: %v", err)
+	}
+
+	res, err := db.Query(ctx, "SELECT last_activity_at FROM conversations WHERE id = 'cnv_2'")
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+
+	if res.Rows[0][0] != float64(19_000) {
+		t.Fatalf("expected cnv_2 to be refreshed, got %v", res.Rows[0][0])
+	}
+}
+
+func TestSyncResumesAfterFailure(t *testing.T) {
+	ctx := context.Background()
+	f := newFakeFront(t, []float64{9000, 8000, 7000, 6000, 5000})
+	db := openTestDB(t)
+	opts := SyncOptions{Resources: []string{ResourceConversations}}
+
+	f.failPage.Store(1)
+
+	if _, err := Sync(ctx, f.client(), db, opts); err == nil {
+		t.Fatal("expected first run to fail")
+	}
+
+	token, err := db.State(ctx, statePageToken)
+	if err != nil || token != "p1" {
+		t.Fatalf("expected saved page token p1, got %q (%v)", token, err)
+	}
+
+	f.messageHit.Store(0)
+
+	stats, err := Sync(ctx, f.client(), db, opts)
+	if err != nil {
+		t.Fatalf("resumed Sync: %v", err)
+	}
+
+	if !stats.Resumed || stats.Conversations != 3 || f.messageHit.Load() != 3 {
+		t.Fatalf("expected resumed run to sync the remaining 3 conversations, stats=%+v", stats)
+	}
+
+	if token, _ := db.State(ctx, statePageToken); token != "" {
+		t.Fatalf("expected page token to be cleared, got %q", token)
+	}
+
+	if got := count(t, db, "conversations"); got != 5 {
+		t.Fatalf("expected 5 conversations, got %d", got)
+	}
+}
+
+func TestSyncRejectsUnknownResource(t *testing.T) {
+	db := openTestDB(t)
+
+	_, err := Sync(context.Background(), nil, db, SyncOptions{Resources: []string{"widgets"}})
+	if err == nil || !strings.Contains(err.Error(), "widgets") {
+		t.Fatalf("expected unknown resource error, got %v", err)
+	}
+}
+
+func TestOpenReadOnlyRequiresSync(t *testing.T) {
+	_, err := OpenReadOnly(context.Background(), filepath.Join(t.TempDir(), "missing.db"))
+	if !errors.Is(err, ErrNotSynced) {
+		t.Fatalf("expected ErrNotSynced, got %v", err)
+	}
+}
+
+func TestOpenReadOnlyRejectsOutdatedSchema(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "test.db")
+
+	db, err := Open(ctx, path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+
+	if _, err := db.db.ExecContext(ctx, fmt.Sprintf("PRAGMA user_version = %d", schemaVersion-1)); err != nil {
+		t.Fatalf("set user_version: %v", err)
+	}
+
+	_ = db.Close()
+
+	_, err = OpenReadOnly(ctx, path)
+	if !errors.Is(err, ErrOutdated) {
+		t.Fatalf("expected ErrOutdated, got %v", err)
+	}
+}
+
+func TestQueryIsReadOnly(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "test.db")
+
+	db, err := Open(ctx, path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+
+	_ = db.Close()
+
+	ro, err := OpenReadOnly(ctx, path)
+	if err != nil {
+		t.Fatalf("OpenReadOnly: %v", err)
+	}
+	defer ro.Close()
+
+	if _, err := ro.Query(ctx, "DELETE FROM conversations"); err == nil {
+		t.Fatal("expected write to fail on read-only database")
+	}
+}
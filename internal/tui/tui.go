@@ -0,0 +1,615 @@
+// Package tui implements the full-screen conversation triage view behind
+// `frontcli tui`.
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+	"github.com/dedene/frontapp-cli/internal/output"
+)
+
+// Options configures the triage view.
+type Options struct {
+	Client *api.Client
+
+	// ListPath is the conversation listing shown on the left, e.g.
+	// "/conversations?q[inbox_id]=inb_1".
+	ListPath string
+
+	// Timeline renders the full message/comment timeline of a conversation.
+	Timeline func(ctx context.Context, convID string) (string, error)
+
+	// ResolveTeammate and ResolveTag turn names, emails or "me" into IDs.
+	ResolveTeammate func(ctx context.Context, ref string) (string, error)
+	ResolveTag      func(ctx context.Context, ref string) (string, error)
+}
+
+// Run shows the triage view until the user quits or ctx is cancelled.
+func Run(ctx context.Context, opts Options) error {
+	p := tea.NewProgram(newModel(ctx, opts), tea.WithAltScreen(), tea.WithContext(ctx))
+
+	if _, err := p.Run(); err != nil && !errors.Is(err, tea.ErrProgramKilled) {
+		return fmt.Errorf("run tui: %w", err)
+	}
+
+	return nil
+}
+
+type pane int
+
+const (
+	listPane pane = iota
+	timelinePane
+)
+
+type promptKind int
+
+const (
+	noPrompt promptKind = iota
+	assignPrompt
+	tagPrompt
+	snoozePrompt
+	replyPrompt
+	commentPrompt
+)
+
+var promptLabels = map[promptKind]string{
+	assignPrompt:  "Assign to (email, name or me)",
+	tagPrompt:     "Add tag (name or ID)",
+	snoozePrompt:  "Snooze for (e.g. 2h) or until (RFC3339)",
+	replyPrompt:   "Reply (ctrl+s to send, esc to cancel)",
+	commentPrompt: "Comment (ctrl+s to post, esc to cancel)",
+}
+
+// selectDelay debounces timeline loading while the cursor moves.
+const selectDelay = 250 * time.Millisecond
+
+type (
+	convsLoadedMsg struct {
+		convs []api.Conversation
+		err   error
+	}
+	selectMsg   struct{ id string }
+	timelineMsg struct {
+		id   string
+		text string
+		err  error
+	}
+	actionMsg struct {
+		status string
+		err    error
+	}
+)
+
+var (
+	selectedStyle = lipgloss.NewStyle().Reverse(true)
+	dimStyle      = lipgloss.NewStyle().Faint(true)
+	errorStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+	titleStyle    = lipgloss.NewStyle().Bold(true)
+	paneStyle     = lipgloss.NewStyle().Border(lipgloss.RoundedBorder())
+	activeBorder  = lipgloss.Color("4")
+)
+
+type model struct {
+	ctx  context.Context
+	opts Options
+
+	convs  []api.Conversation
+	cursor int
+	offset int
+
+	timelines map[string]string
+	viewport  viewport.Model
+	focus     pane
+
+	prompt   promptKind
+	input    textinput.Model
+	textarea textarea.Model
+
+	status  string
+	err     error
+	loading bool
+
+	width  int
+	height int
+}
+
+func newModel(ctx context.Context, opts Options) *model {
+	input := textinput.New()
+	input.CharLimit = 200
+
+	ta := textarea.New()
+	ta.ShowLineNumbers = false
+
+	return &model{
+		ctx:       ctx,
+		opts:      opts,
+		timelines: make(map[string]string),
+		viewport:  viewport.New(0, 0),
+		input:     input,
+		textarea:  ta,
+		loading:   true,
+	}
+}
+
+func (m *model) Init() tea.Cmd {
+	return m.loadConvs()
+}
+
+func (m *model) loadConvs() tea.Cmd {
+	return func() tea.Msg {
+		var resp api.ListResponse[api.Conversation]
+		err := m.opts.Client.Get(m.ctx, m.opts.ListPath, &resp)
+
+		return convsLoadedMsg{convs: resp.Results, err: err}
+	}
+}
+
+func (m *model) loadTimeline(id string) tea.Cmd {
+	return func() tea.Msg {
+		text, err := m.opts.Timeline(m.ctx, id)
+
+		return timelineMsg{id: id, text: text, err: err}
+	}
+}
+
+func (m *model) selected() (api.Conversation, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.convs) {
+		return api.Conversation{}, false
+	}
+
+	return m.convs[m.cursor], true
+}
+
+// scheduleSelect asks for the selected timeline after selectDelay, so
+// scrolling through the list does not fetch every conversation on the way.
+func (m *model) scheduleSelect() tea.Cmd {
+	conv, ok := m.selected()
+	if !ok {
+		return nil
+	}
+
+	m.showTimeline()
+
+	if _, cached := m.timelines[conv.ID]; cached {
+		return nil
+	}
+
+	return tea.Tick(selectDelay, func(time.Time) tea.Msg { return selectMsg{id: conv.ID} })
+}
+
+func (m *model) showTimeline() {
+	conv, ok := m.selected()
+	if !ok {
+		m.viewport.SetContent("")
+
+		return
+	}
+
+	text, ok := m.timelines[conv.ID]
+	if !ok {
+		text = dimStyle.Render("Loading…")
+	}
+
+	header := titleStyle.Render(conv.Subject) + "\n" + dimStyle.Render(conv.ID+"  "+conv.Status)
+	m.viewport.SetContent(lipgloss.NewStyle().Width(m.viewport.Width).Render(header + "\n" + text))
+	m.viewport.GotoTop()
+}
+
+func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.layout()
+		m.showTimeline()
+
+		return m, nil
+
+	case convsLoadedMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.err = msg.err
+
+			return m, nil
+		}
+
+		m.convs = msg.convs
+		m.cursor = min(m.cursor, max(len(m.convs)-1, 0))
+
+		return m, m.scheduleSelect()
+
+	case selectMsg:
+		if conv, ok := m.selected(); ok && conv.ID == msg.id {
+			return m, m.loadTimeline(msg.id)
+		}
+
+		return m, nil
+
+	case timelineMsg:
+		if msg.err != nil {
+			m.timelines[msg.id] = errorStyle.Render(msg.err.Error())
+		} else {
+			m.timelines[msg.id] = msg.text
+		}
+
+		if conv, ok := m.selected(); ok && conv.ID == msg.id {
+			m.showTimeline()
+		}
+
+		return m, nil
+
+	case actionMsg:
+		m.status, m.err = msg.status, msg.err
+		if msg.err != nil {
+			return m, nil
+		}
+
+		// The action may have changed the conversation or removed it from
+		// the listing, so refetch both.
+		if conv, ok := m.selected(); ok {
+			delete(m.timelines, conv.ID)
+		}
+
+		return m, m.loadConvs()
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+
+		if m.prompt != noPrompt {
+			return m.updatePrompt(msg)
+		}
+
+		return m.updateKeys(msg)
+	}
+
+	return m, nil
+}
+
+func (m *model) updateKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.err = nil
+
+	switch msg.String() {
+	case "q":
+		return m, tea.Quit
+	case "tab":
+		if m.focus == listPane {
+			m.focus = timelinePane
+		} else {
+			m.focus = listPane
+		}
+
+		return m, nil
+	case "enter", "l", "right":
+		m.focus = timelinePane
+
+		return m, nil
+	case "esc", "h", "left":
+		m.focus = listPane
+
+		return m, nil
+	case "R":
+		m.loading = true
+		m.timelines = make(map[string]string)
+
+		return m, m.loadConvs()
+	}
+
+	if m.focus == timelinePane {
+		var cmd tea.Cmd
+		m.viewport, cmd = m.viewport.Update(msg)
+
+		return m, cmd
+	}
+
+	switch msg.String() {
+	case "j", "down":
+		return m, m.move(1)
+	case "k", "up":
+		return m, m.move(-1)
+	case "g", "home":
+		return m, m.move(-len(m.convs))
+	case "G", "end":
+		return m, m.move(len(m.convs))
+	case "a":
+		return m, m.withSelected(m.archive)
+	case "A":
+		return m, m.openPrompt(assignPrompt)
+	case "t":
+		return m, m.openPrompt(tagPrompt)
+	case "s":
+		return m, m.openPrompt(snoozePrompt)
+	case "r":
+		return m, m.openPrompt(replyPrompt)
+	case "c":
+		return m, m.openPrompt(commentPrompt)
+	}
+
+	return m, nil
+}
+
+func (m *model) move(delta int) tea.Cmd {
+	if len(m.convs) == 0 {
+		return nil
+	}
+
+	next := min(max(m.cursor+delta, 0), len(m.convs)-1)
+	if next == m.cursor {
+		return nil
+	}
+
+	m.cursor = next
+
+	return m.scheduleSelect()
+}
+
+func (m *model) openPrompt(kind promptKind) tea.Cmd {
+	if _, ok := m.selected(); !ok {
+		return nil
+	}
+
+	m.prompt = kind
+	m.status = ""
+
+	if kind == replyPrompt || kind == commentPrompt {
+		m.textarea.Reset()
+		m.layout()
+
+		return m.textarea.Focus()
+	}
+
+	m.input.Reset()
+	m.input.Placeholder = ""
+
+	return m.input.Focus()
+}
+
+func (m *model) closePrompt() {
+	m.prompt = noPrompt
+	m.input.Blur()
+	m.textarea.Blur()
+	m.layout()
+}
+
+func (m *model) updatePrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	multiline := m.prompt == replyPrompt || m.prompt == commentPrompt
+
+	switch {
+	case msg.String() == "esc":
+		m.closePrompt()
+
+		return m, nil
+	case multiline && msg.String() == "ctrl+s", !multiline && msg.String() == "enter":
+		value := strings.TrimSpace(m.input.Value())
+		if multiline {
+			value = strings.TrimSpace(m.textarea.Value())
+		}
+
+		kind := m.prompt
+		m.closePrompt()
+
+		if value == "" {
+			return m, nil
+		}
+
+		m.status = "Working…"
+
+		return m, m.withSelected(func(conv api.Conversation) tea.Msg {
+			return m.submit(kind, conv, value)
+		})
+	}
+
+	var cmd tea.Cmd
+	if multiline {
+		m.textarea, cmd = m.textarea.Update(msg)
+	} else {
+		m.input, cmd = m.input.Update(msg)
+	}
+
+	return m, cmd
+}
+
+func (m *model) withSelected(fn func(api.Conversation) tea.Msg) tea.Cmd {
+	conv, ok := m.selected()
+	if !ok {
+		return nil
+	}
+
+	return func() tea.Msg { return fn(conv) }
+}
+
+func (m *model) archive(conv api.Conversation) tea.Msg {
+	err := m.opts.Client.Patch(m.ctx, "/conversations/"+conv.ID, map[string]string{"status": "archived"}, nil)
+
+	return actionMsg{status: "Archived " + conv.ID, err: err}
+}
+
+func (m *model) submit(kind promptKind, conv api.Conversation, value string) tea.Msg {
+	ctx, client := m.ctx, m.opts.Client
+
+	switch kind {
+	case assignPrompt:
+		id, err := m.opts.ResolveTeammate(ctx, value)
+		if err == nil {
+			err = client.Patch(ctx, "/conversations/"+conv.ID, map[string]string{"assignee_id": id}, nil)
+		}
+
+		return actionMsg{status: fmt.Sprintf("Assigned %s to %s", conv.ID, id), err: err}
+	case tagPrompt:
+		id, err := m.opts.ResolveTag(ctx, value)
+		if err == nil {
+			err = client.Post(ctx, fmt.Sprintf("/conversations/%s/tags", conv.ID), map[string][]string{"tag_ids": {id}}, nil)
+		}
+
+		return actionMsg{status: fmt.Sprintf("Tagged %s with %s", conv.ID, id), err: err}
+	case snoozePrompt:
+		until, err := parseSnooze(value, time.Now())
+		if err == nil {
+			err = client.Patch(ctx, fmt.Sprintf("/conversations/%s/reminders", conv.ID), map[string]string{"scheduled_at": until}, nil)
+		}
+
+		return actionMsg{status: fmt.Sprintf("Snoozed %s until %s", conv.ID, until), err: err}
+	case replyPrompt:
+		req := map[string]any{"body": value, "type": "reply"}
+		err := client.Post(ctx, fmt.Sprintf("/conversations/%s/messages", conv.ID), req, nil)
+
+		return actionMsg{status: "Reply sent to " + conv.ID, err: err}
+	case commentPrompt:
+		err := client.Post(ctx, fmt.Sprintf("/conversations/%s/comments", conv.ID), map[string]string{"body": value}, nil)
+
+		return actionMsg{status: "Comment added to " + conv.ID, err: err}
+	default:
+		return nil
+	}
+}
+
+// parseSnooze accepts a duration ("2h") or an RFC3339 timestamp and returns
+// the RFC3339 time to snooze until.
+func parseSnooze(value string, now time.Time) (string, error) {
+	if d, err := time.ParseDuration(value); err == nil {
+		return now.Add(d).UTC().Format(time.RFC3339), nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC().Format(time.RFC3339), nil
+	}
+
+	return "", fmt.Errorf("invalid snooze %q: use a duration like 2h or an RFC3339 time", value)
+}
+
+// layout sizes the panes for the current window and prompt.
+func (m *model) layout() {
+	listWidth := max(m.width*2/5, 30)
+	m.viewport.Width = max(m.width-listWidth-4, 10)
+	m.viewport.Height = max(m.bodyHeight()-2, 1)
+	m.textarea.SetWidth(max(m.width-2, 10))
+	m.textarea.SetHeight(5)
+	m.input.Width = max(m.width-4, 10)
+}
+
+// bodyHeight is the height available to the panes after the prompt and
+// footer lines.
+func (m *model) bodyHeight() int {
+	footer := 2
+
+	switch m.prompt {
+	case noPrompt:
+	case replyPrompt, commentPrompt:
+		footer += m.textarea.Height() + 1
+	default:
+		footer += 2
+	}
+
+	return max(m.height-footer, 3)
+}
+
+func (m *model) View() string {
+	if m.width == 0 {
+		return ""
+	}
+
+	listWidth := max(m.width*2/5, 30)
+	height := m.bodyHeight()
+
+	left := m.listView(listWidth-2, height-2)
+	right := m.viewport.View()
+
+	leftStyle, rightStyle := paneStyle, paneStyle
+	if m.focus == listPane {
+		leftStyle = leftStyle.BorderForeground(activeBorder)
+	} else {
+		rightStyle = rightStyle.BorderForeground(activeBorder)
+	}
+
+	body := lipgloss.JoinHorizontal(lipgloss.Top,
+		leftStyle.Width(listWidth-2).Height(height-2).Render(left),
+		rightStyle.Width(m.viewport.Width).Height(height-2).Render(right),
+	)
+
+	var b strings.Builder
+	b.WriteString(body)
+	b.WriteString("\n")
+
+	switch m.prompt {
+	case noPrompt:
+	case replyPrompt, commentPrompt:
+		b.WriteString(titleStyle.Render(promptLabels[m.prompt]) + "\n")
+		b.WriteString(m.textarea.View() + "\n")
+	default:
+		b.WriteString(titleStyle.Render(promptLabels[m.prompt]) + "\n")
+		b.WriteString(m.input.View() + "\n")
+	}
+
+	b.WriteString(m.statusLine())
+
+	return b.String()
+}
+
+func (m *model) listView(width, height int) string {
+	if m.loading && len(m.convs) == 0 {
+		return dimStyle.Render("Loading conversations…")
+	}
+
+	if len(m.convs) == 0 {
+		return dimStyle.Render("No conversations found.")
+	}
+
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+
+	if m.cursor >= m.offset+height {
+		m.offset = m.cursor - height + 1
+	}
+
+	lines := make([]string, 0, height)
+
+	for i := m.offset; i < len(m.convs) && i < m.offset+height; i++ {
+		conv := m.convs[i]
+
+		assignee := "-"
+		if conv.Assignee != nil {
+			assignee = conv.Assignee.Username
+			if assignee == "" {
+				assignee = conv.Assignee.Email
+			}
+		}
+
+		meta := fmt.Sprintf(" %-10s %s", conv.Status, output.Truncate(assignee, 12))
+		subject := output.Truncate(conv.Subject, max(width-lipgloss.Width(meta)-1, 5))
+		line := subject + strings.Repeat(" ", max(width-lipgloss.Width(subject)-lipgloss.Width(meta), 1)) + dimStyle.Render(meta)
+
+		if i == m.cursor {
+			line = selectedStyle.Render(subject + strings.Repeat(" ", max(width-lipgloss.Width(subject)-lipgloss.Width(meta), 1)) + meta)
+		}
+
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (m *model) statusLine() string {
+	help := "j/k move  tab switch pane  a archive  A assign  t tag  s snooze  r reply  c comment  R refresh  q quit"
+
+	switch {
+	case m.err != nil:
+		return errorStyle.Render(m.err.Error())
+	case m.status != "":
+		return m.status + dimStyle.Render("  ·  "+help)
+	default:
+		return dimStyle.Render(help)
+	}
+}
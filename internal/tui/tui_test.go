@@ -0,0 +1,100 @@
+package tui
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"golang.org/x/oauth2"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+)
+
+func TestParseSnooze(t *testing.T) {
+	now := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+
+	got, err := parseSnooze("2h", now)
+	if err != nil || got != "2026-01-02T12:00:00Z" {
+		t.Fatalf("duration: got %q, %v", got, err)
+	}
+
+	got, err = parseSnooze("2026-01-05T09:00:00+01:00", now)
+	if err != nil || got != "2026-01-05T08:00:00Z" {
+		t.Fatalf("timestamp: got %q, %v", got, err)
+	}
+
+	if _, err := parseSnooze("tomorrow", now); err == nil {
+		t.Fatal("expected error for invalid snooze")
+	}
+}
+
+func key(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func TestModelNavigationAndArchive(t *testing.T) {
+	var patched map[string]string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPatch && r.URL.Path == "/conversations/cnv_2" {
+			_ = json.NewDecoder(r.Body).Decode(&patched)
+			w.WriteHeader(http.StatusNoContent)
+
+			return
+		}
+
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	}))
+	defer srv.Close()
+
+	client := api.NewClientWithBaseURL(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test"}), srv.URL)
+	m := newModel(context.Background(), Options{Client: client})
+	m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m.Update(convsLoadedMsg{convs: []api.Conversation{
+		{ID: "cnv_1", Subject: "First", Status: "open"},
+		{ID: "cnv_2", Subject: "Second", Status: "open"},
+	}})
+
+	m.Update(key("j"))
+	m.Update(key("j"))
+
+	if conv, _ := m.selected(); conv.ID != "cnv_2" {
+		t.Fatalf("selected = %s, want cnv_2", conv.ID)
+	}
+
+	_, cmd := m.Update(key("a"))
+	if cmd == nil {
+		t.Fatal("archive returned no command")
+	}
+
+	msg, ok := cmd().(actionMsg)
+	if !ok || msg.err != nil {
+		t.Fatalf("archive result = %#v", msg)
+	}
+
+	if patched["status"] != "archived" {
+		t.Fatalf("patch body = %v", patched)
+	}
+}
+
+func TestModelPromptCancel(t *testing.T) {
+	m := newModel(context.Background(), Options{})
+	m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m.Update(convsLoadedMsg{convs: []api.Conversation{{ID: "cnv_1"}}})
+
+	m.Update(key("A"))
+
+	if m.prompt != assignPrompt {
+		t.Fatalf("prompt = %v, want assign", m.prompt)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+
+	if m.prompt != noPrompt {
+		t.Fatalf("prompt = %v after esc, want none", m.prompt)
+	}
+}
@@ -0,0 +1,150 @@
+package webhook
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+)
+
+// Event types sent by Front. The list is not exhaustive; unknown types are
+// decoded like any other event.
+const (
+	EventInbound  = "inbound"   // new inbound message
+	EventOutbound = "outbound"  // new outbound message
+	EventOutReply = "out_reply" // outbound reply
+	EventAssign   = "assign"
+	EventUnassign = "unassign"
+	EventTag      = "tag"
+	EventUntag    = "untag"
+	EventComment  = "comment"
+	EventMention  = "mention"
+	EventArchive  = "archive"
+	EventReopen   = "reopen"
+	EventTrash    = "trash"
+	EventRestore  = "restore"
+	EventMove     = "move"
+	EventReminder = "reminder"
+)
+
+// ErrInvalidEvent is returned for payloads that are not Front events.
+var ErrInvalidEvent = errors.New("invalid webhook event")
+
+// Event is a Front event. Source is what triggered it (a teammate, a rule,
+// ...) and Target what it applies to (a message, a tag, a teammate, ...);
+// use the typed accessors to decode them.
+type Event struct {
+	ID           string            `json:"id"`
+	Type         string            `json:"type"`
+	EmittedAt    float64           `json:"emitted_at"`
+	Conversation *api.Conversation `json:"conversation,omitempty"`
+	Source       *Resource         `json:"source,omitempty"`
+	Target       *Resource         `json:"target,omitempty"`
+	// CompanyID is set for application webhooks.
+	CompanyID string `json:"company_id,omitempty"`
+}
+
+// Resource is the source or target of an event.
+type Resource struct {
+	Meta ResourceMeta    `json:"_meta"` //nolint:tagliatelle // Front API
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// ResourceMeta names the kind of a Resource: message, comment, tag,
+// teammate, rule, inboxes, ...
+type ResourceMeta struct {
+	Type string `json:"type"`
+}
+
+// appEnvelope wraps events sent to application webhooks.
+type appEnvelope struct {
+	Type          string `json:"type"`
+	Authorization *struct {
+		ID string `json:"id"`
+	} `json:"authorization"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// ParseEvent decodes a webhook body. Both rule webhooks, which send the
+// event itself, and application webhooks, which wrap it in a payload, are
+// accepted.
+func ParseEvent(body []byte) (*Event, error) {
+	var env appEnvelope
+	if err := json.Unmarshal(body, &env); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidEvent, err)
+	}
+
+	raw := body
+	if len(env.Payload) > 0 && string(env.Payload) != "null" {
+		raw = env.Payload
+	}
+
+	var ev Event
+	if err := json.Unmarshal(raw, &ev); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidEvent, err)
+	}
+
+	if ev.Type == "" {
+		ev.Type = env.Type
+	}
+
+	if ev.Type == "" {
+		return nil, fmt.Errorf("%w: no event type", ErrInvalidEvent)
+	}
+
+	if env.Authorization != nil {
+		ev.CompanyID = env.Authorization.ID
+	}
+
+	return &ev, nil
+}
+
+// decode unmarshals r's data when it is of the given kind.
+func decode[T any](r *Resource, kind string) (*T, bool) {
+	if r == nil || r.Meta.Type != kind || len(r.Data) == 0 {
+		return nil, false
+	}
+
+	var v T
+	if err := json.Unmarshal(r.Data, &v); err != nil {
+		return nil, false
+	}
+
+	return &v, true
+}
+
+// Message returns the message of inbound, outbound and reply events.
+func (e *Event) Message() (*api.Message, bool) {
+	return decode[api.Message](e.Target, "message")
+}
+
+// Comment returns the comment of comment and mention events.
+func (e *Event) Comment() (*api.Comment, bool) {
+	return decode[api.Comment](e.Target, "comment")
+}
+
+// Tag returns the tag added or removed by tag and untag events.
+func (e *Event) Tag() (*api.Tag, bool) {
+	return decode[api.Tag](e.Target, "tag")
+}
+
+// Assignee returns the teammate a conversation was assigned to, or
+// unassigned from.
+func (e *Event) Assignee() (*api.Teammate, bool) {
+	return decode[api.Teammate](e.Target, "teammate")
+}
+
+// Actor returns the teammate who triggered the event, if any.
+func (e *Event) Actor() (*api.Teammate, bool) {
+	return decode[api.Teammate](e.Source, "teammate")
+}
+
+// ConversationID returns the ID of the event's conversation, if any.
+func (e *Event) ConversationID() string {
+	if e.Conversation == nil {
+		return ""
+	}
+
+	return e.Conversation.ID
+}
@@ -0,0 +1,83 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// MaxBodySize caps the size of a webhook request body.
+const MaxBodySize = 10 << 20
+
+// Handler is an http.Handler receiving Front webhooks. It rejects requests
+// with invalid signatures, answers validation challenges and passes every
+// other event to OnEvent. When OnEvent fails the request gets a 500 so Front
+// retries it.
+type Handler struct {
+	Verifier Verifier
+	OnEvent  func(context.Context, *Event) error
+	// OnError, if set, is called with requests that were rejected or failed.
+	OnError func(*http.Request, error)
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxBodySize))
+	if err != nil {
+		h.fail(w, r, http.StatusRequestEntityTooLarge, err)
+
+		return
+	}
+
+	if err := h.Verifier.Verify(r.Header, body); err != nil {
+		h.fail(w, r, http.StatusUnauthorized, err)
+
+		return
+	}
+
+	// Front validates application webhook URLs by expecting the challenge
+	// back.
+	if challenge := r.Header.Get(HeaderChallenge); challenge != "" {
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = io.WriteString(w, challenge)
+
+		return
+	}
+
+	ev, err := ParseEvent(body)
+	if err != nil {
+		h.fail(w, r, http.StatusBadRequest, err)
+
+		return
+	}
+
+	if h.OnEvent != nil {
+		if err := h.OnEvent(r.Context(), ev); err != nil {
+			h.fail(w, r, http.StatusInternalServerError, err)
+
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) fail(w http.ResponseWriter, r *http.Request, status int, err error) {
+	if h.OnError != nil {
+		h.OnError(r, err)
+	}
+
+	msg := http.StatusText(status)
+	if errors.Is(err, ErrInvalidEvent) {
+		msg = err.Error()
+	}
+
+	http.Error(w, msg, status)
+}
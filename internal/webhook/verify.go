@@ -0,0 +1,151 @@
+// Package webhook receives Front webhooks: it verifies request signatures,
+// answers validation challenges and decodes events.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec // Front signs rule webhooks with HMAC-SHA1
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Headers set by Front on webhook requests.
+const (
+	HeaderSignature = "X-Front-Signature"
+	HeaderTimestamp = "X-Front-Request-Timestamp"
+	HeaderChallenge = "X-Front-Challenge"
+)
+
+// DefaultTolerance is how far a request timestamp may be from the local
+// clock before the request is rejected as a replay.
+const DefaultTolerance = 5 * time.Minute
+
+var (
+	ErrMissingSecret    = errors.New("webhook secret is empty")
+	ErrMissingSignature = errors.New("missing " + HeaderSignature + " header")
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrMissingTimestamp = errors.New("missing " + HeaderTimestamp + " header")
+	ErrInvalidTimestamp = errors.New("invalid " + HeaderTimestamp + " header")
+	ErrStaleTimestamp   = errors.New("webhook timestamp outside the allowed window")
+)
+
+// Sign returns the signature of an application webhook: the base64
+// HMAC-SHA256 of "timestamp:body" keyed with the app secret.
+func Sign(secret, timestamp string, body []byte) string {
+	return sign(sha256.New, secret, []byte(timestamp+":"), body)
+}
+
+// SignLegacy returns the signature of a rule webhook: the base64 HMAC-SHA1
+// of the body keyed with the API secret.
+func SignLegacy(secret string, body []byte) string {
+	return sign(sha1.New, secret, nil, body)
+}
+
+func sign(h func() hash.Hash, secret string, prefix, body []byte) string {
+	mac := hmac.New(h, []byte(secret))
+	mac.Write(prefix)
+	mac.Write(body)
+
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// Scheme selects how webhook requests are signed.
+type Scheme int
+
+const (
+	// SchemeApp verifies application webhooks: every request must carry a
+	// timestamp within Tolerance and an HMAC-SHA256 signature (see Sign).
+	SchemeApp Scheme = iota
+	// SchemeRule verifies rule webhooks, signed with HMAC-SHA1 of the body
+	// (see SignLegacy).
+	SchemeRule
+)
+
+// Verifier checks webhook signatures with the configured Scheme. The scheme
+// is fixed by the receiver, never taken from the request, so a sender can't
+// downgrade application webhooks to the weaker rule signature.
+type Verifier struct {
+	Secret string
+	// Scheme defaults to SchemeApp.
+	Scheme Scheme
+	// Tolerance defaults to DefaultTolerance. A negative value disables the
+	// timestamp check.
+	Tolerance time.Duration
+	// Now defaults to time.Now.
+	Now func() time.Time
+}
+
+// Verify checks the signature of a request with the given headers and body.
+func (v Verifier) Verify(header http.Header, body []byte) error {
+	if v.Secret == "" {
+		return ErrMissingSecret
+	}
+
+	got := header.Get(HeaderSignature)
+	if got == "" {
+		return ErrMissingSignature
+	}
+
+	var want string
+
+	switch v.Scheme {
+	case SchemeRule:
+		want = SignLegacy(v.Secret, body)
+	default:
+		timestamp := header.Get(HeaderTimestamp)
+		if timestamp == "" {
+			return ErrMissingTimestamp
+		}
+
+		if err := v.checkTimestamp(timestamp); err != nil {
+			return err
+		}
+
+		want = Sign(v.Secret, timestamp, body)
+	}
+
+	if !hmac.Equal([]byte(got), []byte(want)) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+func (v Verifier) checkTimestamp(value string) error {
+	tolerance := v.Tolerance
+	if tolerance == 0 {
+		tolerance = DefaultTolerance
+	}
+
+	if tolerance < 0 {
+		return nil
+	}
+
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: %q", ErrInvalidTimestamp, value)
+	}
+
+	// Front sends milliseconds; accept seconds too.
+	at := time.UnixMilli(n)
+	if n < 1e12 {
+		at = time.Unix(n, 0)
+	}
+
+	now := time.Now()
+	if v.Now != nil {
+		now = v.Now()
+	}
+
+	if d := now.Sub(at); d > tolerance || d < -tolerance {
+		return fmt.Errorf("%w: %s", ErrStaleTimestamp, at.UTC().Format(time.RFC3339))
+	}
+
+	return nil
+}
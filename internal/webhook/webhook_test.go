@@ -0,0 +1,164 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testSecret = "s3cret"
+
+func TestVerifyApplicationSignature(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	body := []byte(`{"type":"inbound"}`)
+	ts := strconv.FormatInt(now.UnixMilli(), 10)
+
+	header := http.Header{}
+	header.Set(HeaderTimestamp, ts)
+	header.Set(HeaderSignature, Sign(testSecret, ts, body))
+
+	v := Verifier{Secret: testSecret, Now: func() time.Time { return now }}
+	if err := v.Verify(header, body); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+
+	if err := v.Verify(header, []byte(`{"type":"outbound"}`)); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("expected ErrInvalidSignature for a tampered body, got %v", err)
+	}
+
+	late := Verifier{Secret: testSecret, Now: func() time.Time { return now.Add(time.Hour) }}
+	if err := late.Verify(header, body); !errors.Is(err, ErrStaleTimestamp) {
+		t.Fatalf("expected ErrStaleTimestamp, got %v", err)
+	}
+
+	// A rule-style signature without a timestamp doesn't pass as an
+	// application webhook.
+	legacy := http.Header{}
+	legacy.Set(HeaderSignature, SignLegacy(testSecret, body))
+
+	if err := v.Verify(legacy, body); !errors.Is(err, ErrMissingTimestamp) {
+		t.Fatalf("expected ErrMissingTimestamp, got %v", err)
+	}
+}
+
+func TestVerifyLegacySignature(t *testing.T) {
+	body := []byte(`{"type":"tag"}`)
+
+	header := http.Header{}
+	header.Set(HeaderSignature, SignLegacy(testSecret, body))
+
+	rule := Verifier{Secret: testSecret, Scheme: SchemeRule}
+	if err := rule.Verify(header, body); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+
+	if err := (Verifier{Secret: "other", Scheme: SchemeRule}).Verify(header, body); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("expected ErrInvalidSignature, got %v", err)
+	}
+
+	if err := rule.Verify(http.Header{}, body); !errors.Is(err, ErrMissingSignature) {
+		t.Fatalf("expected ErrMissingSignature, got %v", err)
+	}
+}
+
+func TestParseEvent(t *testing.T) {
+	rule := `{"id":"evt_1","type":"tag","emitted_at":1700000000.5,
+		"conversation":{"id":"cnv_1","subject":"Hi"},
+		"source":{"_meta":{"type":"teammate"},"data":{"id":"tea_1","email":"a@example.com"}},
+		"target":{"_meta":{"type":"tag"},"data":{"id":"tag_1","name":"vip"}}}`
+
+	ev, err := ParseEvent([]byte(rule))
+	if err != nil {
+		t.Fatalf("ParseEvent: %v", err)
+	}
+
+	tag, ok := ev.Tag()
+	if !ok || tag.Name != "vip" || ev.ConversationID() != "cnv_1" {
+		t.Fatalf("unexpected event: %+v", ev)
+	}
+
+	if actor, ok := ev.Actor(); !ok || actor.Email != "a@example.com" {
+		t.Fatalf("unexpected actor: %+v", actor)
+	}
+
+	if _, ok := ev.Message(); ok {
+		t.Fatal("tag event should have no message")
+	}
+
+	app := `{"type":"inbound","authorization":{"id":"cmp_1"},"payload":{"id":"evt_2","type":"inbound",
+		"target":{"_meta":{"type":"message"},"data":{"id":"msg_1","is_inbound":true,"body":"Hello"}}}}`
+
+	ev, err = ParseEvent([]byte(app))
+	if err != nil {
+		t.Fatalf("ParseEvent: %v", err)
+	}
+
+	msg, ok := ev.Message()
+	if !ok || msg.ID != "msg_1" || !msg.IsInbound || ev.CompanyID != "cmp_1" {
+		t.Fatalf("unexpected event: %+v", ev)
+	}
+
+	if _, err := ParseEvent([]byte(`{"id":"x"}`)); !errors.Is(err, ErrInvalidEvent) {
+		t.Fatalf("expected ErrInvalidEvent, got %v", err)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	var got []*Event
+
+	h := &Handler{
+		Verifier: Verifier{Secret: testSecret},
+		OnEvent: func(_ context.Context, ev *Event) error {
+			got = append(got, ev)
+
+			return nil
+		},
+	}
+
+	post := func(body string, header http.Header) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		for k, v := range header {
+			req.Header[k] = v
+		}
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	signed := func(body string) http.Header {
+		ts := strconv.FormatInt(time.Now().UnixMilli(), 10)
+
+		header := http.Header{}
+		header.Set(HeaderTimestamp, ts)
+		header.Set(HeaderSignature, Sign(testSecret, ts, []byte(body)))
+
+		return header
+	}
+
+	body := `{"id":"evt_1","type":"archive"}`
+	if rec := post(body, signed(body)); rec.Code != http.StatusNoContent {
+		t.Fatalf("signed event: status %d", rec.Code)
+	}
+
+	if rec := post(body, http.Header{HeaderSignature: {"bogus"}}); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("bad signature: status %d", rec.Code)
+	}
+
+	challenge := signed("{}")
+	challenge.Set(HeaderChallenge, "abc123")
+
+	if rec := post("{}", challenge); rec.Code != http.StatusOK || rec.Body.String() != "abc123" {
+		t.Fatalf("challenge: status %d body %q", rec.Code, rec.Body.String())
+	}
+
+	if len(got) != 1 || got[0].Type != EventArchive {
+		t.Fatalf("unexpected events: %+v", got)
+	}
+}